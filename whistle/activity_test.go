@@ -0,0 +1,86 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/amattu2/go-whistle-wrapper/whistle"
+	"github.com/go-playground/assert/v2"
+)
+
+// activityServer serves a pet with a suggested activity range of 30 to 60 minutes
+// and accepts new activity goals
+func activityServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/pets/123", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"pet":{"id":123,"activity_summary":{"suggested_activity_range_lower":30,"suggested_activity_range_upper":60}}}`))
+	})
+	mux.HandleFunc("/api/pets/123/activity_goals", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"activity_goal":{"minutes":45,"started_at":"2026-11-01T00:00:00Z","time_zone":"America/New_York"}}`))
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestSetActivityGoal(t *testing.T) {
+	t.Parallel()
+
+	server := activityServer(t)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.SetActivityGoal("123", 45, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), false)
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, 45, resp.Response.ActivityGoal.Minutes)
+	assert.Equal(t, "America/New_York", resp.Response.ActivityGoal.TimeZone)
+}
+
+func TestSetActivityGoalOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	server := activityServer(t)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+
+	var validationErr *whistle.ValidationError
+	resp := client.SetActivityGoal("123", 90, time.Time{}, false)
+	assert.Equal(t, true, errors.As(resp.Error, &validationErr))
+	assert.Equal(t, "minutes", validationErr.Field)
+
+	resp = client.SetActivityGoal("123", 90, time.Time{}, true)
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = client.SetActivityGoal("123", 0, time.Time{}, true)
+	assert.Equal(t, true, errors.As(resp.Error, &validationErr))
+}
@@ -22,10 +22,10 @@
 package whistle
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
 )
 
 type BreedsResponse struct {
@@ -39,26 +39,11 @@ type Breed struct {
 }
 
 // Breeds returns a list of breeds for a given animal (dogs, cats)
-func (c Client) Breeds(animal string) *HttpResponse[BreedsResponse] {
-	resp, err := c.get(fmt.Sprintf("api/breeds/%s", animal), nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[BreedsResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := BreedsResponse{}
-	json.Unmarshal(body, &result)
+func (c *Client) Breeds(animal string) *HttpResponse[BreedsResponse] {
+	return c.BreedsCtx(context.Background(), animal)
+}
 
-	return &HttpResponse[BreedsResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// BreedsCtx is the context-aware variant of Breeds
+func (c *Client) BreedsCtx(ctx context.Context, animal string) *HttpResponse[BreedsResponse] {
+	return Do[BreedsResponse](ctx, c, http.MethodGet, fmt.Sprintf("api/breeds/%s", url.PathEscape(animal)), nil, nil)
 }
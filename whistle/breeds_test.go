@@ -22,9 +22,11 @@
 package whistle_test
 
 import (
+	"errors"
 	"net/http"
 	"testing"
 
+	"github.com/amattu2/go-whistle-wrapper/whistle"
 	"github.com/go-playground/assert/v2"
 )
 
@@ -58,6 +60,8 @@ func TestBreedsInvalid(t *testing.T) {
 	// Get data
 	resp := c.Breeds("rhinos")
 
+	var apiErr *whistle.APIError
 	assert.NotEqual(t, http.StatusOK, resp.StatusCode)
-	assert.Equal(t, nil, resp.Error) // No internal error, only API error
+	assert.Equal(t, true, errors.As(resp.Error, &apiErr))
+	assert.Equal(t, resp.StatusCode, apiErr.StatusCode)
 }
@@ -23,10 +23,15 @@ package whistle
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -35,13 +40,34 @@ const (
 	StagingEnv = "https://app-staging.whistle.com"
 )
 
+const (
+	defaultTimeout        = 10 * time.Second
+	defaultUserAgent      = "Mozilla/5.0 (X11; Linux x86_64)"
+	defaultAcceptLanguage = "en-US"
+	defaultPollInterval   = 5 * time.Second
+)
+
 type Client struct {
+	// mu guards the credentials and tokens below
+	mu sync.RWMutex
+
 	// API Credentials
 	email, password, refreshToken string
 
 	// API Token or HTTP Bearer
 	token, bearer string
 
+	// pendingLogin is the in-flight login shared by concurrent callers
+	pendingLogin *loginCall
+
+	// rejectedBearer is the last bearer rejected by the API, never reused from the TokenStore
+	rejectedBearer string
+
+	// chainMu guards the Middleware chain, built once from chainMiddleware
+	chainMu         sync.Mutex
+	chain           http.RoundTripper
+	chainMiddleware []Middleware
+
 	// Environment (ProdEnv or StagingEnv)
 	Env string
 
@@ -50,6 +76,40 @@ type Client struct {
 
 	// UserAgent is the User-Agent header to send with each request
 	UserAgent string
+
+	// AcceptLanguage is the Accept-Language header to send with each request (default: en-US)
+	AcceptLanguage string
+
+	// OnTokenRefreshed is called whenever a new bearer is obtained from the login
+	// endpoint, including automatic re-authentication after a HTTP 401
+	OnTokenRefreshed func(bearer string, refreshToken string)
+
+	// HTTPClient is used to send requests when set. Its own Timeout applies instead of Timeout.
+	HTTPClient *http.Client
+
+	// Transport is used to send requests when HTTPClient is not set (default: a shared pooled transport)
+	Transport http.RoundTripper
+
+	// Middleware wraps the transport of every request, the first entry being the outermost.
+	// The chain is built once, and again only when Middleware is replaced.
+	Middleware []Middleware
+
+	// Retry configures retries of failed requests (default: no retries).
+	// Use WithRetryPolicy to override it for a single call.
+	Retry *RetryPolicy
+
+	// RateLimiter limits the rate of requests, including logins (default: unlimited).
+	// It is paused automatically when the API responds with HTTP 429.
+	RateLimiter *RateLimiter
+
+	// TokenStore persists the bearer and refresh token between client instances.
+	// It is keyed by email and Env, and is only used by clients with an email.
+	// Errors returned by the store are ignored and a regular login is performed.
+	TokenStore TokenStore
+
+	// PollInterval is the delay between requests of helpers that wait for the API,
+	// such as WaitForLocate (default: 5 seconds)
+	PollInterval time.Duration
 }
 
 type HttpResponse[T interface{}] struct {
@@ -64,6 +124,9 @@ type HttpResponse[T interface{}] struct {
 
 	// Embedded HTTP Response
 	Raw *http.Response `json:"raw"`
+
+	// Number of HTTP attempts made, including retries
+	Attempts int `json:"attempts"`
 }
 
 type TokenResponse struct {
@@ -106,7 +169,35 @@ type RealtimeChannel struct {
 	Service string `json:"service"`
 }
 
+// loginCall represents a login request that concurrent callers wait on
+type loginCall struct {
+	done chan struct{}
+	err  error
+}
+
+// Credentials are the authentication options accepted by NewClient.
+// Only one of Email/Password, Email/RefreshToken, Bearer or Token is required.
+//
+// A cached Bearer may be combined with Email/RefreshToken so that the client can
+// re-authenticate once the bearer expires.
+type Credentials struct {
+	Email        string `json:"email"`
+	Password     string `json:"password"`
+	RefreshToken string `json:"refresh_token"`
+	Bearer       string `json:"bearer"`
+	Token        string `json:"token"`
+}
+
+// NewClient creates a new client from the provided credentials.
+//
+// Returns ErrMissingCredentials if no usable combination of credentials is provided.
+func NewClient(creds Credentials) (*Client, error) {
+	return New(WithCredentials(creds))
+}
+
 // Initialize creates a new client with email and password credentials.
+//
+// Panics if either value is empty. Use NewClient to receive an error instead.
 func Initialize(email string, password string) *Client {
 	if email == "" || password == "" {
 		panic("valid email and password are required")
@@ -115,12 +206,15 @@ func Initialize(email string, password string) *Client {
 	return &Client{
 		email:     email,
 		password:  password,
-		Timeout:   10 * time.Second,
+		Timeout:   defaultTimeout,
 		Env:       ProdEnv,
-		UserAgent: "Mozilla/5.0 (X11; Linux x86_64)",
+		UserAgent: defaultUserAgent,
 	}
 }
 
+// InitializeRefreshToken creates a new client with email and refresh token credentials.
+//
+// Panics if either value is empty. Use NewClient to receive an error instead.
 func InitializeRefreshToken(email string, refreshToken string) *Client {
 	if email == "" || refreshToken == "" {
 		panic("valid email and refresh token are required")
@@ -129,9 +223,9 @@ func InitializeRefreshToken(email string, refreshToken string) *Client {
 	return &Client{
 		email:        email,
 		refreshToken: refreshToken,
-		Timeout:      10 * time.Second,
+		Timeout:      defaultTimeout,
 		Env:          ProdEnv,
-		UserAgent:    "Mozilla/5.0 (X11; Linux x86_64)",
+		UserAgent:    defaultUserAgent,
 	}
 }
 
@@ -145,13 +239,15 @@ func InitializeToken(token string) *Client {
 
 	return &Client{
 		token:     token,
-		Timeout:   10 * time.Second,
+		Timeout:   defaultTimeout,
 		Env:       ProdEnv,
-		UserAgent: "Mozilla/5.0 (X11; Linux x86_64)",
+		UserAgent: defaultUserAgent,
 	}
 }
 
 // InitializeBearer creates a new client with an existing HTTP bearer token.
+//
+// Panics if the bearer is empty. Use NewClient to receive an error instead.
 func InitializeBearer(bearer string) *Client {
 	if bearer == "" {
 		panic("valid http bearer is required")
@@ -159,146 +255,590 @@ func InitializeBearer(bearer string) *Client {
 
 	return &Client{
 		bearer:    bearer,
-		Timeout:   10 * time.Second,
+		Timeout:   defaultTimeout,
 		Env:       ProdEnv,
-		UserAgent: "Mozilla/5.0 (X11; Linux x86_64)",
+		UserAgent: defaultUserAgent,
 	}
 }
 
 // addDefaultHeaders adds default headers to a Whistle API request
-func (c *Client) addDefaultHeaders(request *http.Request, addAuth bool) {
+func (c *Client) addDefaultHeaders(request *http.Request, addAuth bool) error {
 	// Add headers
 	request.Header.Set("User-Agent", c.UserAgent)
 	request.Header.Set("Referer", "https://app.whistle.com/")
 	request.Header.Set("Content-Type", "application/json")
 	request.Header.Set("Accept", "application/vnd.whistle.com.v4+json")
-	request.Header.Set("Accept-Language", "en-US")
+	request.Header.Set("Accept-Language", c.AcceptLanguage)
+	if c.AcceptLanguage == "" {
+		request.Header.Set("Accept-Language", defaultAcceptLanguage)
+	}
 
 	// Add authorization
 	if addAuth {
-		if c.token != "" {
-			request.Header.Set("X-Whistle-AuthToken", c.GetToken())
+		if token := c.Token(); token != "" {
+			request.Header.Set("X-Whistle-AuthToken", token)
 		} else {
-			request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.GetBearer()))
+			if err := c.login(request.Context()); err != nil {
+				return err
+			}
+
+			request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Bearer()))
+		}
+	}
+
+	return nil
+}
+
+// do makes a HTTP request to the Whistle API, honoring the provided context
+// and retrying according to the RetryPolicy of the call.
+//
+// Returns the response of the last attempt and the number of attempts made.
+func (c *Client) do(ctx context.Context, method string, path string, headers map[string]string, body []byte, addAuth bool) (*http.Response, int, error) {
+	policy := c.retryPolicy(ctx)
+	if policy == nil || policy.MaxAttempts <= 1 || !policy.allowsMethod(method) {
+		resp, err := c.sendAuthorized(ctx, method, path, headers, body, addAuth)
+		return resp, 1, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.sendAuthorized(ctx, method, path, headers, body, addAuth)
+		if attempt >= policy.MaxAttempts || !policy.shouldRetry(resp, err) {
+			return resp, attempt, err
+		}
+
+		// Honor the server requested delay, giving up if it is too long
+		wait := policy.backoff(attempt)
+		if after, ok := retryAfter(resp); ok {
+			if policy.MaxBackoff > 0 && after > policy.MaxBackoff {
+				return resp, attempt, err
+			}
+			if after > wait {
+				wait = after
+			}
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, attempt, err
 		}
 	}
 }
 
-// get makes a HTTP GET request to the Whistle API
-func (c *Client) get(path string, headers map[string]string, addAuth bool) (*http.Response, error) {
+// sendAuthorized makes a HTTP request to the Whistle API.
+//
+// If an authenticated request is rejected with HTTP 401 and the client holds login
+// credentials, the bearer is renewed and the request is retried once.
+func (c *Client) sendAuthorized(ctx context.Context, method string, path string, headers map[string]string, body []byte, addAuth bool) (*http.Response, error) {
+	resp, err := c.send(ctx, method, path, headers, body, addAuth)
+	if err != nil || !addAuth || resp.StatusCode != http.StatusUnauthorized || !c.canLogin() {
+		return resp, err
+	}
+
+	// Discard the rejected bearer and try again with a fresh one
+	resp.Body.Close()
+	c.invalidateBearer(strings.TrimPrefix(resp.Request.Header.Get("Authorization"), "Bearer "))
+
+	return c.send(ctx, method, path, headers, body, addAuth)
+}
+
+// send makes a single HTTP request to the Whistle API
+func (c *Client) send(ctx context.Context, method string, path string, headers map[string]string, body []byte, addAuth bool) (*http.Response, error) {
 	// Initialize the client
-	client := http.Client{}
-	client.Timeout = c.Timeout
+	client := c.httpClient()
 
 	// Initialize the request
-	request, err := http.NewRequest("GET", fmt.Sprintf("%s/%s", c.Env, path), nil)
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/%s", c.Env, path), reader)
 	if err != nil {
 		return nil, err
 	}
 
 	// Add headers
-	c.addDefaultHeaders(request, addAuth)
+	if err := c.addDefaultHeaders(request, addAuth); err != nil {
+		return nil, canceled(ctx, err)
+	}
 	for key, value := range headers {
 		request.Header.Set(key, value)
 	}
 
-	return client.Do(request)
+	if c.RateLimiter != nil {
+		if err := c.RateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := client.Do(request)
+	if err != nil {
+		return nil, canceled(ctx, err)
+	}
+
+	// Slow every caller down when the API starts throttling
+	if c.RateLimiter != nil && resp.StatusCode == http.StatusTooManyRequests {
+		wait, ok := retryAfter(resp)
+		if !ok {
+			wait = defaultThrottle
+		}
+
+		c.RateLimiter.Throttle(wait)
+	}
+
+	return resp, nil
 }
 
 // post makes a HTTP POST request to the Whistle API
-func (c *Client) post(path string, headers map[string]string, body map[string]string, addAuth bool) (*http.Response, error) {
-	// Initialize the client
-	client := http.Client{}
-	client.Timeout = c.Timeout
-
-	// Initialize the request
-	jsonData, _ := json.Marshal(body)
-	request, err := http.NewRequest("POST", fmt.Sprintf("%s/%s", c.Env, path), bytes.NewBuffer(jsonData))
+func (c *Client) post(ctx context.Context, path string, headers map[string]string, body any, addAuth bool) (*http.Response, error) {
+	jsonData, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 
-	// Add headers
-	c.addDefaultHeaders(request, addAuth)
-	for key, value := range headers {
-		request.Header.Set(key, value)
+	resp, _, err := c.do(ctx, http.MethodPost, path, headers, jsonData, addAuth)
+
+	return resp, err
+}
+
+// canceled replaces err with a CanceledError if the context is no longer active
+func canceled(ctx context.Context, err error) error {
+	var canceledErr *CanceledError
+	if ctx.Err() == nil || errors.As(err, &canceledErr) {
+		return err
 	}
 
-	return client.Do(request)
+	return &CanceledError{Err: ctx.Err()}
 }
 
-// GetToken returns the API token if it exists, otherwise it will login and return the token
+// Do makes a HTTP request to the Whistle API and parses the JSON response into T.
+// Every endpoint of the client is built on Do, and it may be used to call endpoints
+// which are not covered by the client with a response type of your own.
 //
-// Deprecated: Use GetBearer() instead
-func (c *Client) GetToken() string {
-	// If token is empty, login and get token
-	if c.token == "" && c.email != "" && c.password != "" {
-		data := map[string]string{
-			"email":    c.email,
-			"password": c.password,
+// path: the endpoint relative to Client.Env (e.g. "api/pets/123"), whose dynamic segments are escaped with url.PathEscape
+//
+// query: the query parameters of the request, if any
+//
+// body: a value marshalled to JSON as the request body, or nil to send none
+func Do[T any](ctx context.Context, c *Client, method string, path string, query url.Values, body any) *HttpResponse[T] {
+	return request[T](ctx, c, method, path, query, nil, body)
+}
+
+// request is Do with additional headers
+//
+// expected: the HTTP status codes considered successful (default: 2xx)
+func request[T any](ctx context.Context, c *Client, method string, path string, query url.Values, headers map[string]string, body any, expected ...int) *HttpResponse[T] {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return invalid[T](err)
 		}
+	}
+
+	resp, attempts, err := c.do(ctx, method, endpoint(path, query), headers, data, true)
+
+	result := parseResponse[T](resp, err, expected...)
+	result.Attempts = attempts
+
+	return result
+}
 
-		resp, err := c.post("api/tokens", nil, data, false)
-		if err != nil {
-			panic(err)
+// endpoint appends the encoded query to path
+func endpoint(path string, query url.Values) string {
+	path = strings.TrimPrefix(path, "/")
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	return path
+}
+
+// Ptr returns a pointer to the provided value, for setting the fields of partial updates
+func Ptr[T any](value T) *T {
+	return &value
+}
+
+// confirm makes a HTTP request to the Whistle API whose response body is ignored, reporting whether it succeeded
+func confirm(ctx context.Context, c *Client, method string, path string, body any) *HttpResponse[bool] {
+	raw := Do[json.RawMessage](ctx, c, method, path, nil, body)
+
+	return &HttpResponse[bool]{
+		StatusCode: raw.StatusCode,
+		Error:      raw.Error,
+		Response:   raw.Error == nil,
+		Raw:        raw.Raw,
+		Attempts:   raw.Attempts,
+	}
+}
+
+// relay copies the status, error and raw response of resp, without its body, into a HttpResponse[T]
+func relay[T any, U any](resp *HttpResponse[U]) *HttpResponse[T] {
+	return &HttpResponse[T]{
+		StatusCode: resp.StatusCode,
+		Error:      resp.Error,
+		Raw:        resp.Raw,
+		Attempts:   resp.Attempts,
+	}
+}
+
+// invalid returns a HttpResponse for a request rejected before being sent
+func invalid[T any](err error) *HttpResponse[T] {
+	return &HttpResponse[T]{Error: err}
+}
+
+// parseResponse converts a raw HTTP response into a HttpResponse, parsing the body on success.
+//
+// Any 2xx or expected status is successful. Other responses are reported as an *APIError
+// and bodies that cannot be decoded as a *DecodeError.
+func parseResponse[T any](resp *http.Response, err error, expected ...int) *HttpResponse[T] {
+	if err != nil {
+		return &HttpResponse[T]{
+			Error: err,
+			Raw:   resp,
 		}
-		if resp.StatusCode != http.StatusOK {
-			panic(fmt.Errorf("auth failed with HTTP error: %d", resp.StatusCode))
+	}
+
+	defer resp.Body.Close()
+
+	result := &HttpResponse[T]{
+		StatusCode: resp.StatusCode,
+		Raw:        resp,
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		result.Error = canceled(resp.Request.Context(), err)
+		return result
+	}
+
+	if !isSuccess(resp.StatusCode, expected) {
+		result.Error = newAPIError(resp, body)
+		return result
+	}
+
+	// Parse json response
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &result.Response); err != nil {
+			result.Error = &DecodeError{Path: resp.Request.URL.Path, Err: err}
 		}
+	}
 
-		defer resp.Body.Close()
+	return result
+}
 
-		// Parse json response
-		body, _ := io.ReadAll(resp.Body)
-		result := TokenResponse{}
-		json.Unmarshal(body, &result)
+// isSuccess checks whether the status code is a 2xx or one of the expected codes
+func isSuccess(status int, expected []int) bool {
+	return (status >= 200 && status < 300) || hasStatus(status, expected)
+}
 
-		if !result.Success {
-			panic("Failed to get token")
+// hasStatus checks whether the status code is one of the expected codes
+func hasStatus(status int, expected []int) bool {
+	for _, code := range expected {
+		if status == code {
+			return true
 		}
+	}
+
+	return false
+}
+
+// Login obtains a HTTP bearer using the email and password or refresh token credentials.
+// It is a no-op if the client already holds a bearer or API token.
+//
+// Returns ErrInvalidCredentials or ErrAuthUnavailable (wrapped in an *APIError when the API responded)
+func (c *Client) Login() error {
+	return c.LoginCtx(context.Background())
+}
 
-		c.token = result.Token
+// LoginCtx is the context-aware variant of Login
+func (c *Client) LoginCtx(ctx context.Context) error {
+	if c.Token() != "" {
+		return nil
 	}
 
-	// Return API token
+	return c.login(ctx)
+}
+
+// Token returns the stored API token without logging in
+func (c *Client) Token() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.token
 }
 
+// Bearer returns the stored HTTP bearer without logging in
+func (c *Client) Bearer() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.bearer
+}
+
+// RefreshToken returns the refresh token provided at initialization or
+// the most recent one returned by the login endpoint
+func (c *Client) RefreshToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.refreshToken
+}
+
+// GetToken returns the API token if it exists, otherwise it will login and return the token
+//
+// Panics if authentication fails.
+//
+// Deprecated: Use GetBearer() instead
+func (c *Client) GetToken() string {
+	return c.GetTokenCtx(context.Background())
+}
+
+// GetTokenCtx is the context-aware variant of GetToken
+//
+// Deprecated: Use GetBearerCtx() instead
+func (c *Client) GetTokenCtx(ctx context.Context) string {
+	if err := c.requestToken(ctx); err != nil {
+		panic(err)
+	}
+
+	// Return API token
+	return c.Token()
+}
+
 // GetBearer returns the HTTP bearer if it exists, otherwise it will login and return the bearer
+//
+// Returns an empty string if the client has no bearer and no login credentials (e.g. only a token).
+// Panics if authentication fails. Use Login to receive an error instead.
 func (c *Client) GetBearer() string {
-	// If bearer is empty, login and get bearer
-	if c.bearer == "" && c.email != "" && (c.password != "" || c.refreshToken != "") {
-		data := map[string]string{
-			"email": c.email,
-		}
-		if c.password != "" {
-			data["password"] = c.password
-		} else {
-			data["refresh_token"] = c.refreshToken
-		}
+	return c.GetBearerCtx(context.Background())
+}
 
-		resp, err := c.post("api/login", nil, data, false)
-		if err != nil {
-			panic(err)
-		}
-		if resp.StatusCode != http.StatusCreated {
-			panic(fmt.Errorf("auth failed with HTTP error: %d", resp.StatusCode))
-		}
+// GetBearerCtx is the context-aware variant of GetBearer
+func (c *Client) GetBearerCtx(ctx context.Context) string {
+	if err := c.login(ctx); err != nil && !errors.Is(err, ErrMissingCredentials) {
+		panic(err)
+	}
+
+	// Return HTTP Bearer
+	return c.Bearer()
+}
 
-		defer resp.Body.Close()
+// requestToken logs in and stores the API token if one is not already present
+func (c *Client) requestToken(ctx context.Context) error {
+	c.mu.RLock()
+	token, email, password := c.token, c.email, c.password
+	c.mu.RUnlock()
 
-		// Parse json response
-		body, _ := io.ReadAll(resp.Body)
-		result := BearerResponse{}
-		json.Unmarshal(body, &result)
+	// If token is empty, login and get token
+	if token != "" || email == "" || password == "" {
+		return nil
+	}
+
+	data := map[string]string{
+		"email":    email,
+		"password": password,
+	}
+
+	resp, err := c.post(ctx, "api/tokens", nil, data, false)
+	if err != nil {
+		return authUnavailable(err)
+	}
 
-		if result.AuthToken == "" {
-			panic("Failed to get bearer")
+	defer resp.Body.Close()
+
+	// Parse json response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return authUnavailable(canceled(ctx, err))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return newAuthError(resp, body)
+	}
+
+	result := TokenResponse{}
+	json.Unmarshal(body, &result)
+
+	if !result.Success || result.Token == "" {
+		return fmt.Errorf("%w: no API token returned", ErrAuthUnavailable)
+	}
+
+	c.mu.Lock()
+	c.token = result.Token
+	c.mu.Unlock()
+
+	return nil
+}
+
+// canLogin reports whether the client holds credentials accepted by the login endpoint
+func (c *Client) canLogin() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.token == "" && c.email != "" && (c.password != "" || c.refreshToken != "")
+}
+
+// invalidateBearer clears the stored bearer if it is the one rejected by the API
+func (c *Client) invalidateBearer(bearer string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.bearer == bearer {
+		c.bearer = ""
+		c.rejectedBearer = bearer
+	}
+}
+
+// tokenKey returns the TokenStore key of the client
+func (c *Client) tokenKey() TokenKey {
+	return TokenKey{Email: c.email, Env: c.Env}
+}
+
+// loadStoredToken reads the tokens of key from the TokenStore, if any
+func (c *Client) loadStoredToken(key TokenKey) *StoredToken {
+	if c.TokenStore == nil || key.Email == "" {
+		return nil
+	}
+
+	stored, err := c.TokenStore.Load(key)
+	if err != nil {
+		return nil
+	}
+
+	return stored
+}
+
+// restoreToken restores the bearer and refresh token loaded from the TokenStore.
+// Returns true if a usable bearer was restored. The caller must hold c.mu.
+func (c *Client) restoreToken(stored *StoredToken) bool {
+	if stored == nil {
+		return false
+	}
+	if stored.RefreshToken != "" {
+		c.refreshToken = stored.RefreshToken
+	}
+	if stored.Bearer == "" || stored.Bearer == c.rejectedBearer {
+		return false
+	}
+
+	c.bearer = stored.Bearer
+
+	return true
+}
+
+// login requests and stores a HTTP bearer if one is not already present.
+// Concurrent callers share a single in-flight login request.
+func (c *Client) login(ctx context.Context) error {
+	c.mu.Lock()
+	if c.bearer != "" {
+		c.mu.Unlock()
+		return nil
+	}
+	if call := c.pendingLogin; call != nil {
+		c.mu.Unlock()
+
+		select {
+		case <-call.done:
+			// The login was abandoned because of the context of another caller
+			var canceledErr *CanceledError
+			if errors.As(call.err, &canceledErr) && ctx.Err() == nil {
+				return c.login(ctx)
+			}
+
+			return call.err
+		case <-ctx.Done():
+			return &CanceledError{Err: ctx.Err()}
 		}
+	}
+
+	call := &loginCall{done: make(chan struct{})}
+	c.pendingLogin = call
+	key := c.tokenKey()
+	c.mu.Unlock()
+
+	// The TokenStore may be slow (e.g. key derivation, file IO), so it is read without holding c.mu
+	stored := c.loadStoredToken(key)
+
+	c.mu.Lock()
+	if c.restoreToken(stored) {
+		c.pendingLogin = nil
+		c.mu.Unlock()
+		close(call.done)
+		return nil
+	}
+	if c.email == "" || (c.password == "" && c.refreshToken == "") {
+		c.pendingLogin = nil
+		c.mu.Unlock()
+		call.err = ErrMissingCredentials
+		close(call.done)
+		return ErrMissingCredentials
+	}
+
+	data := map[string]string{
+		"email": c.email,
+	}
+	if c.password != "" {
+		data["password"] = c.password
+	} else {
+		data["refresh_token"] = c.refreshToken
+	}
+	c.mu.Unlock()
 
+	result, err := c.requestBearer(ctx, data)
+
+	c.mu.Lock()
+	if err == nil {
 		c.bearer = result.AuthToken
+		if result.RefreshToken != "" {
+			c.refreshToken = result.RefreshToken
+		}
+	}
+	bearer, refreshToken := c.bearer, c.refreshToken
+	c.pendingLogin = nil
+	c.mu.Unlock()
+
+	call.err = err
+	close(call.done)
+
+	if err == nil && c.TokenStore != nil {
+		c.TokenStore.Save(key, StoredToken{
+			Bearer:       bearer,
+			RefreshToken: refreshToken,
+			UpdatedAt:    time.Now(),
+		})
+	}
+	if err == nil && c.OnTokenRefreshed != nil {
+		c.OnTokenRefreshed(bearer, refreshToken)
 	}
 
-	// Return HTTP Bearer
-	return c.bearer
+	return err
+}
+
+// requestBearer calls the login endpoint with the provided credentials
+func (c *Client) requestBearer(ctx context.Context, data map[string]string) (*BearerResponse, error) {
+	resp, err := c.post(ctx, "api/login", nil, data, false)
+	if err != nil {
+		return nil, authUnavailable(err)
+	}
+
+	defer resp.Body.Close()
+
+	// Parse json response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, authUnavailable(canceled(ctx, err))
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return nil, newAuthError(resp, body)
+	}
+
+	result := BearerResponse{}
+	json.Unmarshal(body, &result)
+
+	if result.AuthToken == "" {
+		return nil, fmt.Errorf("%w: no auth token returned", ErrAuthUnavailable)
+	}
+
+	return &result, nil
 }
@@ -22,7 +22,16 @@
 package whistle_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/amattu2/go-whistle-wrapper/utils"
 	"github.com/amattu2/go-whistle-wrapper/whistle"
@@ -73,3 +82,333 @@ func TestInvalidRefreshInit(t *testing.T) {
 		whistle.InitializeRefreshToken("abc@gmail.com", "")
 	}, "valid email and refresh token are required")
 }
+
+func TestCanceledContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	resp := whistle.InitializeBearer("abc123").MeCtx(ctx)
+
+	var canceledErr *whistle.CanceledError
+	assert.Equal(t, true, errors.As(resp.Error, &canceledErr))
+	assert.Equal(t, true, errors.Is(resp.Error, context.Canceled))
+	assert.Equal(t, 0, resp.StatusCode)
+}
+
+func TestDeadlineExceeded(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	resp := whistle.InitializeBearer("abc123").PetsCtx(ctx)
+
+	assert.Equal(t, true, errors.Is(resp.Error, context.DeadlineExceeded))
+}
+
+func TestNewClientMissingCredentials(t *testing.T) {
+	t.Parallel()
+
+	client, err := whistle.NewClient(whistle.Credentials{Email: "abc@gmail.com"})
+
+	assert.Equal(t, true, errors.Is(err, whistle.ErrMissingCredentials))
+	assert.Equal(t, true, client == nil)
+}
+
+func TestNewClientBearer(t *testing.T) {
+	t.Parallel()
+
+	client, err := whistle.NewClient(whistle.Credentials{Bearer: "abc123"})
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "abc123", client.GetBearer())
+	assert.Equal(t, nil, client.Login())
+}
+
+func TestGetBearerWithoutCredentials(t *testing.T) {
+	t.Parallel()
+
+	client := whistle.InitializeToken("tok")
+
+	assert.Equal(t, "", client.GetBearer())
+	assert.Equal(t, "tok", client.Token())
+}
+
+func TestLoginInvalidCredentials(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"errors":[{"message":"Invalid email or password","code":"invalid_credentials"}]}`))
+	}))
+	defer server.Close()
+
+	client, _ := whistle.NewClient(whistle.Credentials{Email: "abc@gmail.com", Password: "xyz"})
+	client.Env = server.URL
+	err := client.Login()
+
+	var apiErr *whistle.APIError
+	assert.Equal(t, true, errors.Is(err, whistle.ErrInvalidCredentials))
+	assert.Equal(t, true, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+	assert.Equal(t, "Invalid email or password", apiErr.Errors[0].Message)
+}
+
+func TestLoginUnavailable(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client, _ := whistle.NewClient(whistle.Credentials{Email: "abc@gmail.com", RefreshToken: "xyz"})
+	client.Env = server.URL
+	resp := client.Pets()
+
+	assert.Equal(t, true, errors.Is(resp.Error, whistle.ErrAuthUnavailable))
+	assert.Equal(t, true, errors.Is(client.Login(), whistle.ErrAuthUnavailable))
+}
+
+func TestLoginSharedAcrossCalls(t *testing.T) {
+	t.Parallel()
+
+	var logins int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/login" {
+			atomic.AddInt32(&logins, 1)
+			time.Sleep(50 * time.Millisecond)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"auth_token":"bearer123","refresh_token":"refresh456"}`))
+			return
+		}
+
+		assert.Equal(t, "Bearer bearer123", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"pets":[]}`))
+	}))
+	defer server.Close()
+
+	client, _ := whistle.NewClient(whistle.Credentials{Email: "abc@gmail.com", Password: "xyz"})
+	client.Env = server.URL
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Pets()
+		}()
+	}
+	wg.Wait()
+	client.Pets()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&logins))
+	assert.Equal(t, "bearer123", client.Bearer())
+	assert.Equal(t, "refresh456", client.RefreshToken())
+}
+
+func TestLoginSharedAfterCanceled(t *testing.T) {
+	t.Parallel()
+
+	var logins int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/login" {
+			if atomic.AddInt32(&logins, 1) == 1 {
+				time.Sleep(200 * time.Millisecond)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"auth_token":"bearer123"}`))
+			return
+		}
+
+		w.Write([]byte(`{"pets":[]}`))
+	}))
+	defer server.Close()
+
+	client, _ := whistle.NewClient(whistle.Credentials{Email: "abc@gmail.com", Password: "xyz"})
+	client.Env = server.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	leader := make(chan error)
+	go func() {
+		leader <- client.PetsCtx(ctx).Error
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	// The deadline of the first caller must not fail the second one
+	resp := client.Pets()
+
+	assert.Equal(t, true, errors.Is(<-leader, context.DeadlineExceeded))
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&logins))
+	assert.Equal(t, "bearer123", client.Bearer())
+}
+
+func TestReauthenticateOnUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	var logins int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/login" {
+			atomic.AddInt32(&logins, 1)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"auth_token":"fresh","refresh_token":"refresh2"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Write([]byte(`{"pets":[{"id":1,"name":"Barker"}]}`))
+	}))
+	defer server.Close()
+
+	client, _ := whistle.NewClient(whistle.Credentials{Email: "abc@gmail.com", RefreshToken: "refresh1", Bearer: "stale"})
+	client.Env = server.URL
+
+	var refreshed []string
+	client.OnTokenRefreshed = func(bearer string, refreshToken string) {
+		refreshed = append(refreshed, bearer, refreshToken)
+	}
+
+	resp := client.Pets()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Barker", resp.Response.Pets[0].Name)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&logins))
+	assert.Equal(t, []string{"fresh", "refresh2"}, refreshed)
+}
+
+// capturedRequest is a request received by a captureServer
+type capturedRequest struct {
+	Method  string
+	Path    string
+	RawPath string
+	Query   url.Values
+	Body    map[string]any
+}
+
+// captureServer answers every request with the provided status code and body,
+// recording the last request received
+func captureServer(status int, response string) (*httptest.Server, func() capturedRequest) {
+	var mu sync.Mutex
+	var last capturedRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		captured := capturedRequest{Method: r.Method, Path: r.URL.Path, RawPath: r.URL.EscapedPath(), Query: r.URL.Query()}
+		json.NewDecoder(r.Body).Decode(&captured.Body)
+
+		mu.Lock()
+		last = captured
+		mu.Unlock()
+
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	}))
+
+	return server, func() capturedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return last
+	}
+}
+
+func TestUnauthorizedWithoutCredentials(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := whistle.InitializeBearer("stale")
+	client.Env = server.URL
+
+	assert.Equal(t, http.StatusUnauthorized, client.Pets().StatusCode)
+}
+
+func TestDoCustomResponse(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusOK, `{"id":3,"label":"Front door","enabled":true}`)
+	defer server.Close()
+
+	type doorRequest struct {
+		Label   string `json:"label"`
+		Enabled bool   `json:"enabled"`
+		Pets    []int  `json:"pet_ids"`
+	}
+	type door struct {
+		ID      int    `json:"id"`
+		Label   string `json:"label"`
+		Enabled bool   `json:"enabled"`
+	}
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := whistle.Do[door](context.Background(), client, http.MethodPatch, "api/pet_doors/3", nil, doorRequest{
+		Label:   "Front door",
+		Enabled: true,
+		Pets:    []int{1, 2},
+	})
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, door{ID: 3, Label: "Front door", Enabled: true}, resp.Response)
+	assert.Equal(t, http.MethodPatch, last().Method)
+	assert.Equal(t, "/api/pet_doors/3", last().Path)
+	assert.Equal(t, map[string]any{
+		"label":   "Front door",
+		"enabled": true,
+		"pet_ids": []any{1.0, 2.0},
+	}, last().Body)
+}
+
+func TestDoEscaping(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusNoContent, "")
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := whistle.Do[struct{}](context.Background(), client, http.MethodDelete, "/api/pets/"+url.PathEscape("../users/me")+"/owners", url.Values{
+		"note": {"a&b=c"},
+	}, nil)
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "/api/pets/..%2Fusers%2Fme/owners", last().RawPath)
+	assert.Equal(t, "a&b=c", last().Query.Get("note"))
+
+	// Identifiers are escaped where the endpoint paths are built
+	client.Pet("a/b")
+	assert.Equal(t, "/api/pets/a%2Fb", last().RawPath)
+
+	client.Pet("a%2Fb")
+	assert.Equal(t, "/api/pets/a%252Fb", last().RawPath)
+}
+
+func TestDoInvalidBody(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := whistle.Do[struct{}](context.Background(), client, http.MethodPost, "api/pets", nil, make(chan int))
+
+	var unsupported *json.UnsupportedTypeError
+	assert.Equal(t, true, errors.As(resp.Error, &unsupported))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+}
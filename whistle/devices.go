@@ -22,10 +22,14 @@
 package whistle
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 type DeviceResponse struct {
@@ -96,7 +100,22 @@ type DeviceSubscriptionResponse struct {
 }
 
 type DeviceSubscriptionPreviewResponse struct {
-	Error string `json:"error"`
+	Error   string              `json:"error"`
+	Preview SubscriptionPreview `json:"preview"`
+}
+
+type SubscriptionPreview struct {
+	PlanId          string  `json:"plan_id"`
+	Subtotal        float64 `json:"subtotal"`
+	Discount        float64 `json:"discount"`
+	ProratedAmount  float64 `json:"prorated_amount"`
+	CreditAmount    float64 `json:"credit_amount"`
+	Tax             float64 `json:"tax"`
+	Total           float64 `json:"total"`
+	Currency        string  `json:"currency"`
+	EffectiveOn     string  `json:"effective_on"`
+	NextBillingDate string  `json:"next_billing_date"`
+	Coupon          Coupon  `json:"coupon"`
 }
 
 type DeviceUpgradePreviewResponse struct {
@@ -115,177 +134,287 @@ type WifiNetwork struct {
 	PetIds  []int  `json:"pet_ids"`
 }
 
-// Device gets detailed information about a smart collar device by deviceId
-func (c Client) Device(deviceId string) *HttpResponse[DeviceResponse] {
-	resp, err := c.get(fmt.Sprintf("api/devices/%s", deviceId), nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[DeviceResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
+const (
+	FlashlightOn  = "1"
+	FlashlightOff = "0"
 
-	defer resp.Body.Close()
+	TrackingModeOn  = "tracking"
+	TrackingModeOff = "not_tracking"
+)
 
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := DeviceResponse{}
-	json.Unmarshal(body, &result)
+const (
+	ActivationStatusComplete = "complete"
+	ActivationStatusFailed   = "failed"
+)
 
-	return &HttpResponse[DeviceResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// ActivationProgress describes a step of a device activation reported by ActivateDevice
+type ActivationProgress struct {
+	// Serial number of the device
+	Serial string
+
+	// Status of the activation
+	Status string
+
+	// Event completed by this step (see UserActivation.Events)
+	Event string
 }
 
-// DeviceActivationCheck returns HTTP 204 if the device is not activated, ortherwise HTTP 422
-func (c Client) DeviceActivationCheck(deviceId string) *HttpResponse[DeviceActivationResponse] {
-	resp, err := c.get(fmt.Sprintf("api/devices/%s/activation", deviceId), nil, true)
-	if err != nil || (resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusUnprocessableEntity) {
-		return &HttpResponse[DeviceActivationResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
+// Device gets detailed information about a smart collar device by deviceId
+func (c *Client) Device(deviceId string) *HttpResponse[DeviceResponse] {
+	return c.DeviceCtx(context.Background(), deviceId)
+}
 
-	defer resp.Body.Close()
+// DeviceCtx is the context-aware variant of Device
+func (c *Client) DeviceCtx(ctx context.Context, deviceId string) *HttpResponse[DeviceResponse] {
+	return Do[DeviceResponse](ctx, c, http.MethodGet, fmt.Sprintf("api/devices/%s", url.PathEscape(deviceId)), nil, nil)
+}
 
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := DeviceActivationResponse{}
-	json.Unmarshal(body, &result)
+// DeviceActivationCheck returns HTTP 204 if the device is not activated, ortherwise HTTP 422
+func (c *Client) DeviceActivationCheck(deviceId string) *HttpResponse[DeviceActivationResponse] {
+	return c.DeviceActivationCheckCtx(context.Background(), deviceId)
+}
 
-	return &HttpResponse[DeviceActivationResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// DeviceActivationCheckCtx is the context-aware variant of DeviceActivationCheck
+func (c *Client) DeviceActivationCheckCtx(ctx context.Context, deviceId string) *HttpResponse[DeviceActivationResponse] {
+	return request[DeviceActivationResponse](ctx, c, http.MethodGet, fmt.Sprintf("api/devices/%s/activation", url.PathEscape(deviceId)), nil, nil, nil, http.StatusNoContent, http.StatusUnprocessableEntity)
 }
 
 // DevicePlans provides the available plans for a device by deviceId
-func (c Client) DevicePlans(deviceId string) *HttpResponse[DevicePlansResponse] {
-	resp, err := c.get(fmt.Sprintf("api/devices/%s/plans", deviceId), nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[DevicePlansResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
+func (c *Client) DevicePlans(deviceId string) *HttpResponse[DevicePlansResponse] {
+	return c.DevicePlansCtx(context.Background(), deviceId)
+}
 
-	defer resp.Body.Close()
+// DevicePlansCtx is the context-aware variant of DevicePlans
+func (c *Client) DevicePlansCtx(ctx context.Context, deviceId string) *HttpResponse[DevicePlansResponse] {
+	return Do[DevicePlansResponse](ctx, c, http.MethodGet, fmt.Sprintf("api/devices/%s/plans", url.PathEscape(deviceId)), nil, nil)
+}
 
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := DevicePlansResponse{}
-	json.Unmarshal(body, &result)
+// DeviceSubscription returns detailed information about device subscription by deviceId
+func (c *Client) DeviceSubscription(deviceId string) *HttpResponse[DeviceSubscriptionResponse] {
+	return c.DeviceSubscriptionCtx(context.Background(), deviceId)
+}
 
-	return &HttpResponse[DevicePlansResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// DeviceSubscriptionCtx is the context-aware variant of DeviceSubscription
+func (c *Client) DeviceSubscriptionCtx(ctx context.Context, deviceId string) *HttpResponse[DeviceSubscriptionResponse] {
+	return Do[DeviceSubscriptionResponse](ctx, c, http.MethodGet, fmt.Sprintf("api/devices/%s/subscription", url.PathEscape(deviceId)), nil, nil)
 }
 
-// DeviceSubscription returns detailed information about device subscription by deviceId
-func (c Client) DeviceSubscription(deviceId string) *HttpResponse[DeviceSubscriptionResponse] {
-	resp, err := c.get(fmt.Sprintf("api/devices/%s/subscription", deviceId), nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[DeviceSubscriptionResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
+// DeviceSubscriptionPreview gets information about device subscription renewal by deviceId and planId
+func (c *Client) DeviceSubscriptionPreview(deviceId string, planId string) *HttpResponse[DeviceSubscriptionPreviewResponse] {
+	return c.DeviceSubscriptionPreviewCtx(context.Background(), deviceId, planId)
+}
 
-	defer resp.Body.Close()
+// DeviceSubscriptionPreviewCtx is the context-aware variant of DeviceSubscriptionPreview
+func (c *Client) DeviceSubscriptionPreviewCtx(ctx context.Context, deviceId string, planId string) *HttpResponse[DeviceSubscriptionPreviewResponse] {
+	return Do[DeviceSubscriptionPreviewResponse](ctx, c, http.MethodGet, fmt.Sprintf("api/devices/%s/subscription/previews/%s", url.PathEscape(deviceId), url.PathEscape(planId)), nil, nil)
+}
 
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := DeviceSubscriptionResponse{}
-	json.Unmarshal(body, &result)
+// DeviceUpgradePreview returns information about device upgrade by deviceId
+func (c *Client) DeviceUpgradePreview(deviceId string) *HttpResponse[DeviceUpgradePreviewResponse] {
+	return c.DeviceUpgradePreviewCtx(context.Background(), deviceId)
+}
 
-	return &HttpResponse[DeviceSubscriptionResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// DeviceUpgradePreviewCtx is the context-aware variant of DeviceUpgradePreview
+func (c *Client) DeviceUpgradePreviewCtx(ctx context.Context, deviceId string) *HttpResponse[DeviceUpgradePreviewResponse] {
+	return Do[DeviceUpgradePreviewResponse](ctx, c, http.MethodGet, fmt.Sprintf("api/devices/%s/upgrade/preview", url.PathEscape(deviceId)), nil, nil)
 }
 
-// DeviceSubscriptionPreview gets information about device subscription renewal by deviceId and planId
-func (c Client) DeviceSubscriptionPreview(deviceId string, planId string) *HttpResponse[DeviceSubscriptionPreviewResponse] {
-	resp, err := c.get(fmt.Sprintf("api/devices/%s/subscription/previews/%s", deviceId, planId), nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[DeviceSubscriptionPreviewResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
+// DeviceWifiNetworks returns information about Wifi networks a device has connected to
+func (c *Client) DeviceWifiNetworks(deviceId string) *HttpResponse[DeviceWifiNetworksResponse] {
+	return c.DeviceWifiNetworksCtx(context.Background(), deviceId)
+}
+
+// DeviceWifiNetworksCtx is the context-aware variant of DeviceWifiNetworks
+func (c *Client) DeviceWifiNetworksCtx(ctx context.Context, deviceId string) *HttpResponse[DeviceWifiNetworksResponse] {
+	return Do[DeviceWifiNetworksResponse](ctx, c, http.MethodGet, fmt.Sprintf("api/devices/%s/wifi_networks", url.PathEscape(deviceId)), nil, nil)
+}
+
+// SetFlashlight turns the flashlight of a device on or off (FlashlightOn, FlashlightOff)
+func (c *Client) SetFlashlight(deviceId string, mode string) *HttpResponse[DeviceResponse] {
+	return c.SetFlashlightCtx(context.Background(), deviceId, mode)
+}
+
+// SetFlashlightCtx is the context-aware variant of SetFlashlight
+func (c *Client) SetFlashlightCtx(ctx context.Context, deviceId string, mode string) *HttpResponse[DeviceResponse] {
+	if mode != FlashlightOn && mode != FlashlightOff {
+		return invalid[DeviceResponse](&ValidationError{Field: "flashlight_status", Reason: fmt.Sprintf("must be %q or %q", FlashlightOn, FlashlightOff)})
 	}
 
-	defer resp.Body.Close()
+	return Do[DeviceResponse](ctx, c, http.MethodPut, fmt.Sprintf("api/devices/%s/flashlight_status", url.PathEscape(deviceId)), nil, map[string]string{"flashlight_status": mode})
+}
+
+// RequestLocate asks a device to report its location as soon as possible.
+//
+// The device reports PendingLocate until it has checked in, see WaitForLocate.
+// The endpoint is not part of the documented collection and is assumed from the
+// pending_locate field of Device.
+func (c *Client) RequestLocate(deviceId string) *HttpResponse[DeviceResponse] {
+	return c.RequestLocateCtx(context.Background(), deviceId)
+}
+
+// RequestLocateCtx is the context-aware variant of RequestLocate
+func (c *Client) RequestLocateCtx(ctx context.Context, deviceId string) *HttpResponse[DeviceResponse] {
+	return Do[DeviceResponse](ctx, c, http.MethodPut, fmt.Sprintf("api/devices/%s/pending_locate", url.PathEscape(deviceId)), nil, map[string]bool{"pending_locate": true})
+}
 
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := DeviceSubscriptionPreviewResponse{}
-	json.Unmarshal(body, &result)
+// SetTrackingMode enables or disables live tracking of a device (TrackingModeOn, TrackingModeOff).
+//
+// The endpoint and its values are not part of the documented collection and are assumed
+// from the tracking_status field of Device.
+func (c *Client) SetTrackingMode(deviceId string, mode string) *HttpResponse[DeviceResponse] {
+	return c.SetTrackingModeCtx(context.Background(), deviceId, mode)
+}
 
-	return &HttpResponse[DeviceSubscriptionPreviewResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
+// SetTrackingModeCtx is the context-aware variant of SetTrackingMode
+func (c *Client) SetTrackingModeCtx(ctx context.Context, deviceId string, mode string) *HttpResponse[DeviceResponse] {
+	if mode != TrackingModeOn && mode != TrackingModeOff {
+		return invalid[DeviceResponse](&ValidationError{Field: "tracking_status", Reason: fmt.Sprintf("must be %q or %q", TrackingModeOn, TrackingModeOff)})
 	}
+
+	return Do[DeviceResponse](ctx, c, http.MethodPut, fmt.Sprintf("api/devices/%s/tracking_status", url.PathEscape(deviceId)), nil, map[string]string{"tracking_status": mode})
 }
 
-// DeviceUpgradePreview returns information about device upgrade by deviceId
-func (c Client) DeviceUpgradePreview(deviceId string) *HttpResponse[DeviceUpgradePreviewResponse] {
-	resp, err := c.get(fmt.Sprintf("api/devices/%s/upgrade/preview", deviceId), nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[DeviceUpgradePreviewResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
+// WaitForLocate polls a device every PollInterval until its pending locate request
+// has completed, then returns the most recent location of the pet wearing it.
+//
+// The wait is bounded by the context only, use context.WithTimeout to limit it.
+// Returns ErrDeviceNotAssigned if none of the user's pets is wearing the device.
+//
+// The API does not document when a locate completes; it is assumed to be once the
+// pending_locate field of Device (see RequestLocate) is cleared.
+func (c *Client) WaitForLocate(ctx context.Context, deviceId string) (*Location, error) {
+	interval := c.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	for {
+		device := c.DeviceCtx(ctx, deviceId)
+		if device.Error != nil {
+			return nil, device.Error
+		}
+		if !device.Response.Device.PendingLocate {
+			break
+		}
+
+		if err := sleep(ctx, interval); err != nil {
+			return nil, err
 		}
 	}
 
-	defer resp.Body.Close()
+	pets := c.PetsCtx(ctx)
+	if pets.Error != nil {
+		return nil, pets.Error
+	}
 
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := DeviceUpgradePreviewResponse{}
-	json.Unmarshal(body, &result)
+	for _, pet := range pets.Response.Pets {
+		if pet.Device.SerialNumber != deviceId {
+			continue
+		}
 
-	return &HttpResponse[DeviceUpgradePreviewResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
+		locations := c.PetLocationsRecentCtx(ctx, strconv.Itoa(pet.ID))
+		if locations.Error != nil {
+			return nil, locations.Error
+		}
+		if len(locations.Response.Locations) > 0 {
+			return &locations.Response.Locations[0], nil
+		}
+
+		return &pet.LastLocation, nil
 	}
+
+	return nil, ErrDeviceNotAssigned
 }
 
-// DeviceWifiNetworks returns information about Wifi networks a device has connected to
-func (c Client) DeviceWifiNetworks(deviceId string) *HttpResponse[DeviceWifiNetworksResponse] {
-	resp, err := c.get(fmt.Sprintf("api/devices/%s/wifi_networks", deviceId), nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[DeviceWifiNetworksResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
+// ActivateDevice activates a device on a pet with the provided subscription plan (see DevicePlans),
+// polling every PollInterval until the activation completes. Each completed step is reported to
+// progress, which may be nil.
+//
+// The wait is bounded by the context only, use context.WithTimeout to limit it.
+// Returns ErrDeviceAlreadyBound, ErrSubscriptionRequired or ErrActivationFailed (wrapped in an
+// *APIError when the API rejected the request) if the device cannot be activated. An activation
+// which reports a failed status returns ErrActivationFailed along with the activation.
+func (c *Client) ActivateDevice(ctx context.Context, serial string, petId string, planId string, progress func(ActivationProgress)) (*UserActivation, error) {
+	// HTTP 422 indicates the device is already activated
+	check := request[DeviceActivationResponse](ctx, c, http.MethodGet, fmt.Sprintf("api/devices/%s/activation", url.PathEscape(serial)), nil, nil, nil, http.StatusNoContent)
+	if check.Error != nil {
+		var apiErr *APIError
+		if errors.As(check.Error, &apiErr) && apiErr.StatusCode == http.StatusUnprocessableEntity {
+			apiErr.kind = ErrDeviceAlreadyBound
 		}
+
+		return nil, check.Error
 	}
 
-	defer resp.Body.Close()
+	start := Do[DeviceActivationResponse](ctx, c, http.MethodPost, fmt.Sprintf("api/devices/%s/activation/start", url.PathEscape(serial)), nil, map[string]string{
+		"pet_id":  petId,
+		"plan_id": planId,
+	})
+	if start.Error != nil {
+		return nil, activationError(start.Error)
+	}
 
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := DeviceWifiNetworksResponse{}
-	json.Unmarshal(body, &result)
+	interval := c.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	reported := map[string]bool{}
+	for {
+		me := c.MeCtx(ctx)
+		if me.Error != nil {
+			return nil, me.Error
+		}
 
-	return &HttpResponse[DeviceWifiNetworksResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
+		for _, activation := range me.Response.User.UserActivations {
+			if activation.DeviceSerial != serial {
+				continue
+			}
+
+			// Report the newly completed events in a stable order
+			events := make([]string, 0, len(activation.Events))
+			for event, done := range activation.Events {
+				if done && !reported[event] {
+					events = append(events, event)
+				}
+			}
+			sort.Strings(events)
+			for _, event := range events {
+				reported[event] = true
+				if progress != nil {
+					progress(ActivationProgress{Serial: serial, Status: activation.Status, Event: event})
+				}
+			}
+
+			switch activation.Status {
+			case ActivationStatusComplete:
+				return &activation, nil
+			case ActivationStatusFailed:
+				return &activation, ErrActivationFailed
+			}
+		}
+
+		if err := sleep(ctx, interval); err != nil {
+			return nil, err
+		}
 	}
 }
+
+// activationError classifies the error returned when starting a device activation
+func activationError(err error) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	switch {
+	case apiErr.StatusCode == http.StatusPaymentRequired, strings.Contains(apiErr.Code, "subscription"):
+		apiErr.kind = ErrSubscriptionRequired
+	case apiErr.StatusCode == http.StatusConflict, apiErr.StatusCode == http.StatusUnprocessableEntity:
+		apiErr.kind = ErrDeviceAlreadyBound
+	default:
+		apiErr.kind = ErrActivationFailed
+	}
+
+	return apiErr
+}
@@ -22,10 +22,17 @@
 package whistle_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/amattu2/go-whistle-wrapper/utils"
+	"github.com/amattu2/go-whistle-wrapper/whistle"
 	"github.com/go-playground/assert/v2"
 )
 
@@ -72,3 +79,182 @@ func TestDeviceUpgradePreview(t *testing.T) {
 func TestDeviceWifiNetworks(t *testing.T) {
 	t.Skip("Cannot test due to dependence on changing states")
 }
+
+func TestSetFlashlight(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusOK, `{"device":{"flashlight_status":"1"}}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.SetFlashlight("ABC123", whistle.FlashlightOn)
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, whistle.FlashlightOn, resp.Response.Device.FlashlightStatus)
+	assert.Equal(t, http.MethodPut, last().Method)
+	assert.Equal(t, "/api/devices/ABC123/flashlight_status", last().Path)
+	assert.Equal(t, map[string]any{"flashlight_status": "1"}, last().Body)
+}
+
+func TestDeviceCommandsInvalidMode(t *testing.T) {
+	t.Parallel()
+
+	var validationErr *whistle.ValidationError
+	client := whistle.InitializeBearer("abc123")
+
+	assert.Equal(t, true, errors.As(client.SetFlashlight("ABC123", "strobe").Error, &validationErr))
+	assert.Equal(t, true, errors.As(client.SetTrackingMode("ABC123", "sometimes").Error, &validationErr))
+}
+
+func TestWaitForLocate(t *testing.T) {
+	t.Parallel()
+
+	var polls int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/devices/ABC123", func(w http.ResponseWriter, r *http.Request) {
+		pending := atomic.AddInt32(&polls, 1) < 3
+		fmt.Fprintf(w, `{"device":{"serial_number":"ABC123","pending_locate":%t}}`, pending)
+	})
+	mux.HandleFunc("/api/pets", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"pets":[{"id":1,"device":{"serial_number":"OTHER"}},{"id":2,"device":{"serial_number":"ABC123"}}]}`))
+	})
+	mux.HandleFunc("/api/pets/2/locations/recent_trackings", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"locations":[{"latitude":38.8977,"longitude":-77.0365}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	client.PollInterval = time.Millisecond
+
+	location, err := client.WaitForLocate(context.Background(), "ABC123")
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 38.8977, location.Latitude)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&polls))
+}
+
+func TestWaitForLocateTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"device":{"pending_locate":true}}`))
+	}))
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	client.PollInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.WaitForLocate(ctx, "ABC123")
+
+	assert.Equal(t, true, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestWaitForLocateNotAssigned(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/devices/ABC123", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"device":{"pending_locate":false}}`))
+	})
+	mux.HandleFunc("/api/pets", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"pets":[]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+
+	_, err := client.WaitForLocate(context.Background(), "ABC123")
+
+	assert.Equal(t, whistle.ErrDeviceNotAssigned, err)
+}
+
+func TestActivateDevice(t *testing.T) {
+	t.Parallel()
+
+	var polls int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/devices/ABC123/activation", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/api/devices/ABC123/activation/start", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/api/users/me", func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&polls, 1) {
+		case 1:
+			w.Write([]byte(`{"user":{"user_activations":[{"device_serial":"ABC123","status":"pending","events":{"started":true,"paired":false}}]}}`))
+		default:
+			w.Write([]byte(`{"user":{"user_activations":[{"device_serial":"ABC123","status":"complete","events":{"started":true,"paired":true}}]}}`))
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	client.PollInterval = time.Millisecond
+
+	var steps []string
+	activation, err := client.ActivateDevice(context.Background(), "ABC123", "1", "plan1", func(p whistle.ActivationProgress) {
+		steps = append(steps, p.Event)
+	})
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, whistle.ActivationStatusComplete, activation.Status)
+	assert.Equal(t, []string{"started", "paired"}, steps)
+}
+
+func TestActivateDeviceAlreadyBound(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"error":"Device is already activated"}`))
+	}))
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+
+	_, err := client.ActivateDevice(context.Background(), "ABC123", "1", "plan1", nil)
+
+	var apiErr *whistle.APIError
+	assert.Equal(t, true, errors.Is(err, whistle.ErrDeviceAlreadyBound))
+	assert.Equal(t, true, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+	assert.Equal(t, "Device is already activated", apiErr.Message)
+}
+
+func TestActivateDeviceSubscriptionRequired(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/devices/ABC123/activation", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/api/devices/ABC123/activation/start", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+		w.Write([]byte(`{"errors":[{"code":"subscription_required","message":"A subscription is required"}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+
+	_, err := client.ActivateDevice(context.Background(), "ABC123", "1", "plan1", nil)
+
+	var apiErr *whistle.APIError
+	assert.Equal(t, true, errors.Is(err, whistle.ErrSubscriptionRequired))
+	assert.Equal(t, true, errors.As(err, &apiErr))
+	assert.Equal(t, "A subscription is required", apiErr.Message)
+}
@@ -0,0 +1,213 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// maxErrorBodyExcerpt is the length of the raw body kept in an APIError
+const maxErrorBodyExcerpt = 512
+
+var (
+	// ErrMissingCredentials is returned when a client is created without usable credentials
+	ErrMissingCredentials = errors.New("whistle: valid credentials are required")
+
+	// ErrInvalidCredentials is returned when the Whistle API rejects the login credentials
+	ErrInvalidCredentials = errors.New("whistle: invalid credentials")
+
+	// ErrAuthUnavailable is returned when the login endpoint cannot be reached or fails unexpectedly
+	ErrAuthUnavailable = errors.New("whistle: authentication unavailable")
+
+	// ErrDeviceNotAssigned is returned when no pet of the user is wearing a device
+	ErrDeviceNotAssigned = errors.New("whistle: device is not assigned to a pet")
+
+	// ErrSubscriptionRequired is returned when a device cannot be activated without a subscription
+	ErrSubscriptionRequired = errors.New("whistle: subscription required")
+
+	// ErrDeviceAlreadyBound is returned when a device is already activated on an account
+	ErrDeviceAlreadyBound = errors.New("whistle: device is already activated")
+
+	// ErrActivationFailed is returned when the API reports that a device activation failed
+	ErrActivationFailed = errors.New("whistle: device activation failed")
+)
+
+// APIError describes a non-successful response returned by the Whistle API
+type APIError struct {
+	// HTTP Status Code
+	StatusCode int `json:"status_code"`
+
+	// Code, Message and Field of the first error reported by the API, if any
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Field   string `json:"field"`
+
+	// Method and Path of the failed request
+	Method string `json:"method"`
+	Path   string `json:"path"`
+
+	// Body is an excerpt of the raw response body
+	Body string `json:"body"`
+
+	// Decoded error body, if the API provided one
+	Errors []Error `json:"errors"`
+
+	// kind is the sentinel error this API error represents, if any
+	kind error
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("whistle: HTTP %d", e.StatusCode)
+	if e.Path != "" {
+		msg = fmt.Sprintf("whistle: %s %s: HTTP %d", e.Method, e.Path, e.StatusCode)
+	}
+	if e.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Message)
+	}
+	if e.Code != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, e.Code)
+	}
+
+	return msg
+}
+
+func (e *APIError) Unwrap() error {
+	return e.kind
+}
+
+// CanceledError is returned when a request is abandoned because its context was canceled
+// or its deadline was exceeded. The context error is available through errors.Is.
+type CanceledError struct {
+	Err error
+}
+
+func (e *CanceledError) Error() string {
+	return fmt.Sprintf("whistle: request canceled: %s", e.Err)
+}
+
+func (e *CanceledError) Unwrap() error {
+	return e.Err
+}
+
+// newAPIError builds an APIError from a response and its (possibly empty) body.
+//
+// The API reports errors as {"errors": [...]}, {"error": "..."} or {"message": "..."}
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+	}
+	if len(body) > maxErrorBodyExcerpt {
+		apiErr.Body = string(body[:maxErrorBodyExcerpt]) + "..."
+	}
+	if resp.Request != nil && resp.Request.URL != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Path = resp.Request.URL.Path
+	}
+
+	result := struct {
+		Errors  []Error         `json:"errors"`
+		Error   json.RawMessage `json:"error"`
+		Message string          `json:"message"`
+		Code    string          `json:"code"`
+	}{}
+	json.Unmarshal(body, &result)
+
+	apiErr.Errors = result.Errors
+	apiErr.Message, apiErr.Code = result.Message, result.Code
+	if len(result.Error) > 0 {
+		var message string
+		var detail Error
+		if json.Unmarshal(result.Error, &message) == nil {
+			apiErr.Message = message
+		} else if json.Unmarshal(result.Error, &detail) == nil {
+			apiErr.Errors = append(apiErr.Errors, detail)
+		}
+	}
+	if len(apiErr.Errors) > 0 {
+		apiErr.Code = apiErr.Errors[0].Code
+		apiErr.Message = apiErr.Errors[0].Message
+		apiErr.Field = apiErr.Errors[0].Field
+	}
+
+	return apiErr
+}
+
+// DecodeError is returned when a successful response body cannot be decoded
+type DecodeError struct {
+	// Path of the request
+	Path string
+
+	// Err is the JSON decoding error
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("whistle: unable to decode response of %s: %s", e.Path, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError is returned when a request is rejected locally, before being sent to the API
+type ValidationError struct {
+	// Field that failed validation
+	Field string
+
+	// Reason the value was rejected
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("whistle: invalid %s: %s", e.Field, e.Reason)
+}
+
+// newAuthError builds the error returned for a failed login attempt
+func newAuthError(resp *http.Response, body []byte) *APIError {
+	statusCode := resp.StatusCode
+	apiErr := newAPIError(resp, body)
+
+	switch {
+	case statusCode >= http.StatusInternalServerError, statusCode == http.StatusTooManyRequests:
+		apiErr.kind = ErrAuthUnavailable
+	case statusCode >= http.StatusBadRequest:
+		apiErr.kind = ErrInvalidCredentials
+	default:
+		apiErr.kind = ErrAuthUnavailable
+	}
+
+	return apiErr
+}
+
+// authUnavailable wraps a transport error raised during login, preserving cancellation errors
+func authUnavailable(err error) error {
+	var canceledErr *CanceledError
+	if errors.As(err, &canceledErr) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %v", ErrAuthUnavailable, err)
+}
@@ -0,0 +1,206 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/amattu2/go-whistle-wrapper/whistle"
+	"github.com/go-playground/assert/v2"
+)
+
+// errorServer answers every request with the provided status code and body
+func errorServer(status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestAPIErrorsList(t *testing.T) {
+	t.Parallel()
+
+	server := errorServer(http.StatusNotFound, `{"errors":[{"message":"Pet not found","code":"not_found","field":"pet_id"}]}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.Pet("123")
+
+	var apiErr *whistle.APIError
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, true, errors.As(resp.Error, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "not_found", apiErr.Code)
+	assert.Equal(t, "Pet not found", apiErr.Message)
+	assert.Equal(t, "pet_id", apiErr.Field)
+	assert.Equal(t, http.MethodGet, apiErr.Method)
+	assert.Equal(t, "/api/pets/123", apiErr.Path)
+}
+
+func TestAPIErrorString(t *testing.T) {
+	t.Parallel()
+
+	server := errorServer(http.StatusPaymentRequired, `{"error":"Subscription required"}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.Pets()
+
+	var apiErr *whistle.APIError
+	assert.Equal(t, true, errors.As(resp.Error, &apiErr))
+	assert.Equal(t, "Subscription required", apiErr.Message)
+	assert.Equal(t, `{"error":"Subscription required"}`, apiErr.Body)
+	assert.Equal(t, true, strings.Contains(apiErr.Error(), "Subscription required"))
+}
+
+func TestAPIErrorBodyExcerpt(t *testing.T) {
+	t.Parallel()
+
+	server := errorServer(http.StatusInternalServerError, strings.Repeat("x", 2048))
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	client.Retry = whistle.NoRetry()
+	resp := client.Pets()
+
+	var apiErr *whistle.APIError
+	assert.Equal(t, true, errors.As(resp.Error, &apiErr))
+	assert.Equal(t, "", apiErr.Message)
+	assert.Equal(t, true, len(apiErr.Body) < 1024)
+}
+
+func TestDecodeError(t *testing.T) {
+	t.Parallel()
+
+	server := errorServer(http.StatusOK, `{"pets":`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.Pets()
+
+	var decodeErr *whistle.DecodeError
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, true, errors.As(resp.Error, &decodeErr))
+}
+
+func TestExpectedStatusIsNotError(t *testing.T) {
+	t.Parallel()
+
+	server := errorServer(http.StatusNotFound, "")
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.CheckEmail("abc@gmail.com")
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, false, resp.Response)
+}
+
+// documentedPayload returns the body of a request documented in the Thunder Client
+// collection, with its numeric placeholders replaced by example values
+func documentedPayload(t *testing.T, name string) string {
+	data, err := os.ReadFile("../.vscode/thunder-tests/thunderclient.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var requests []struct {
+		Name string `json:"name"`
+		Body struct {
+			Raw string `json:"raw"`
+		} `json:"body"`
+	}
+	if err := json.Unmarshal(data, &requests); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, request := range requests {
+		if request.Name == name {
+			return strings.NewReplacer(`"{{petId}}"`, "123", "{{deviceId}}", "ABC123").Replace(request.Body.Raw)
+		}
+	}
+
+	t.Fatalf("no documented payload named %q", name)
+	return ""
+}
+
+func TestDecodeDocumentedPayloads(t *testing.T) {
+	t.Parallel()
+
+	pet := documentedPayload(t, "Pet Create")
+	place := documentedPayload(t, "Places Create")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/pets":
+			w.Write([]byte(`{"pets":[` + pet + `]}`))
+		case "/api/pets/0":
+			w.Write([]byte(`{"pet":` + pet + `}`))
+		case "/api/places":
+			w.Write([]byte(`[` + place + `]`))
+		}
+	}))
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+
+	pets := client.Pets()
+	assert.Equal(t, nil, pets.Error)
+	assert.Equal(t, "Barker Sarker", pets.Response.Pets[0].Name)
+	assert.Equal(t, whistle.StringList{}, pets.Response.Pets[0].SubscriptionStatus)
+
+	resp := client.Pet("0")
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, 18, resp.Response.Pet.Profile.Breed.ID)
+	assert.Equal(t, "ABC123", resp.Response.Pet.Device.SerialNumber)
+
+	places := client.Places()
+	assert.Equal(t, nil, places.Error)
+	assert.Equal(t, whistle.LatLon{Latitude: 37.123456, Longitude: -122.123456}, places.Response[0].Outline[0])
+	assert.Equal(t, []int{123}, places.Response[0].PetIds)
+}
+
+func TestStringList(t *testing.T) {
+	t.Parallel()
+
+	for body, expected := range map[string]whistle.StringList{
+		`{"subscription_status":"active"}`:           {"active"},
+		`{"subscription_status":["active","trial"]}`: {"active", "trial"},
+		`{"subscription_status":[]}`:                 {},
+		`{"subscription_status":null}`:               nil,
+	} {
+		var pet whistle.Pet
+		assert.Equal(t, nil, json.Unmarshal([]byte(body), &pet))
+		assert.Equal(t, expected, pet.SubscriptionStatus)
+	}
+}
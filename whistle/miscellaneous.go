@@ -22,10 +22,12 @@
 package whistle
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
-	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
 type NotificationsResponse struct {
@@ -63,6 +65,8 @@ type Notification struct {
 }
 
 type NotificationItem struct {
+	ID int `json:"id"`
+
 	// Not present in all responses
 	Actor NotificationItemActor `json:"actor"`
 
@@ -99,131 +103,111 @@ type LatLon struct {
 	Longitude float64 `json:"longitude"`
 }
 
-type Coupon struct {
-}
-
-// Notifications returns a list of the pending notifications for the user.
-func (c Client) Notifications() *HttpResponse[NotificationsResponse] {
-	resp, err := c.get("api/notifications", nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[NotificationsResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
+// UnmarshalJSON decodes the coordinates, which the API sends as numbers or strings
+func (l *LatLon) UnmarshalJSON(data []byte) error {
+	var point struct {
+		Latitude  json.RawMessage `json:"latitude"`
+		Longitude json.RawMessage `json:"longitude"`
+	}
+	if err := json.Unmarshal(data, &point); err != nil {
+		return err
 	}
 
-	defer resp.Body.Close()
+	var err error
+	if l.Latitude, err = parseCoordinate(point.Latitude); err != nil {
+		return err
+	}
+	l.Longitude, err = parseCoordinate(point.Longitude)
 
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := NotificationsResponse{}
-	json.Unmarshal(body, &result)
+	return err
+}
 
-	return &HttpResponse[NotificationsResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
+// parseCoordinate parses a coordinate sent as a number, a string or null
+func parseCoordinate(data json.RawMessage) (float64, error) {
+	value := string(bytes.Trim(data, `"`))
+	if value == "" || value == "null" {
+		return 0, nil
 	}
+
+	return strconv.ParseFloat(value, 64)
 }
 
-// PetFoods lists the pet foods by food type (dog_treat, dog_food)
-func (c Client) PetFoods(foodType string) *HttpResponse[[]PetFood] {
-	resp, err := c.get(fmt.Sprintf("api/pet_foods?type=%s", foodType), nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[[]PetFood]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
+// StringList is a list of strings which the API may also send as a single string
+type StringList []string
+
+// UnmarshalJSON decodes a list of strings, a single string or null
+func (l *StringList) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*l = nil
+		if value != "" {
+			*l = StringList{value}
 		}
-	}
 
-	defer resp.Body.Close()
+		return nil
+	}
 
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := []PetFood{}
-	json.Unmarshal(body, &result)
+	return json.Unmarshal(data, (*[]string)(l))
+}
 
-	return &HttpResponse[[]PetFood]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+type Coupon struct {
+	ID               string  `json:"id"`
+	Code             string  `json:"code"`
+	Name             string  `json:"name"`
+	Valid            bool    `json:"valid"`
+	PercentOff       float64 `json:"percent_off"`
+	AmountOff        float64 `json:"amount_off"`
+	Currency         string  `json:"currency"`
+	Duration         string  `json:"duration"`
+	DurationInMonths int     `json:"duration_in_months"`
 }
 
-// ReverseGeocode returns the best address guess of a given latitude and longitude
-func (c Client) ReverseGeocode(lat string, lon string) *HttpResponse[ReverseGeocodeResponse] {
-	resp, err := c.get(fmt.Sprintf("api/reverse_geocode?latitude=%s&longitude=%s", lat, lon), nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[ReverseGeocodeResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
+// Notifications returns a list of the pending notifications for the user.
+func (c *Client) Notifications() *HttpResponse[NotificationsResponse] {
+	return c.NotificationsCtx(context.Background())
+}
 
-	defer resp.Body.Close()
+// NotificationsCtx is the context-aware variant of Notifications
+func (c *Client) NotificationsCtx(ctx context.Context) *HttpResponse[NotificationsResponse] {
+	return Do[NotificationsResponse](ctx, c, http.MethodGet, "api/notifications", nil, nil)
+}
 
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := ReverseGeocodeResponse{}
-	json.Unmarshal(body, &result)
+// PetFoods lists the pet foods by food type (dog_treat, dog_food)
+func (c *Client) PetFoods(foodType string) *HttpResponse[[]PetFood] {
+	return c.PetFoodsCtx(context.Background(), foodType)
+}
 
-	return &HttpResponse[ReverseGeocodeResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// PetFoodsCtx is the context-aware variant of PetFoods
+func (c *Client) PetFoodsCtx(ctx context.Context, foodType string) *HttpResponse[[]PetFood] {
+	return Do[[]PetFood](ctx, c, http.MethodGet, "api/pet_foods", url.Values{"type": {foodType}}, nil)
 }
 
-// Places returns a list of places tied to the current user
-func (c Client) Places() *HttpResponse[[]Place] {
-	resp, err := c.get("api/places", nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[[]Place]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
+// ReverseGeocode returns the best address guess of a given latitude and longitude
+func (c *Client) ReverseGeocode(lat string, lon string) *HttpResponse[ReverseGeocodeResponse] {
+	return c.ReverseGeocodeCtx(context.Background(), lat, lon)
+}
 
-	defer resp.Body.Close()
+// ReverseGeocodeCtx is the context-aware variant of ReverseGeocode
+func (c *Client) ReverseGeocodeCtx(ctx context.Context, lat string, lon string) *HttpResponse[ReverseGeocodeResponse] {
+	return Do[ReverseGeocodeResponse](ctx, c, http.MethodGet, "api/reverse_geocode", url.Values{"latitude": {lat}, "longitude": {lon}}, nil)
+}
 
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := []Place{}
-	json.Unmarshal(body, &result)
+// Places returns a list of places tied to the current user
+func (c *Client) Places() *HttpResponse[[]Place] {
+	return c.PlacesCtx(context.Background())
+}
 
-	return &HttpResponse[[]Place]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// PlacesCtx is the context-aware variant of Places
+func (c *Client) PlacesCtx(ctx context.Context) *HttpResponse[[]Place] {
+	return Do[[]Place](ctx, c, http.MethodGet, "api/places", nil, nil)
 }
 
 // AdventureCategories returns a list of adventure categories
-func (c Client) AdventureCategories() *HttpResponse[AdventureCategoriesResponse] {
-	// Get data
-	resp, err := c.get("api/adventures/categories", nil, true)
-	if err != nil || (resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent) {
-		return &HttpResponse[AdventureCategoriesResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := AdventureCategoriesResponse{}
-	json.Unmarshal(body, &result)
+func (c *Client) AdventureCategories() *HttpResponse[AdventureCategoriesResponse] {
+	return c.AdventureCategoriesCtx(context.Background())
+}
 
-	return &HttpResponse[AdventureCategoriesResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// AdventureCategoriesCtx is the context-aware variant of AdventureCategories
+func (c *Client) AdventureCategoriesCtx(ctx context.Context) *HttpResponse[AdventureCategoriesResponse] {
+	return request[AdventureCategoriesResponse](ctx, c, http.MethodGet, "api/adventures/categories", nil, nil, nil, http.StatusOK, http.StatusNoContent)
 }
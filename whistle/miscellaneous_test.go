@@ -22,9 +22,11 @@
 package whistle_test
 
 import (
+	"errors"
 	"net/http"
 	"testing"
 
+	"github.com/amattu2/go-whistle-wrapper/whistle"
 	"github.com/go-playground/assert/v2"
 )
 
@@ -86,7 +88,8 @@ func TestPetFoodsInvalidType(t *testing.T) {
 
 	resp := c.PetFoods("rhino_treat")
 
-	assert.Equal(t, nil, resp.Error)
+	var apiErr *whistle.APIError
+	assert.Equal(t, true, errors.As(resp.Error, &apiErr))
 	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
 }
 
@@ -0,0 +1,157 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// defaultNotificationsPerPage is the page size used by EachNotification
+const defaultNotificationsPerPage = 25
+
+// NotificationFilter selects notifications by type and read state
+type NotificationFilter struct {
+	// Types of notification to keep (see NotificationItem.NotificationType), all if empty
+	Types []string
+
+	// UnreadOnly keeps only the unread notifications
+	UnreadOnly bool
+}
+
+// Match reports whether the notification is selected by the filter
+func (f NotificationFilter) Match(item NotificationItem) bool {
+	if f.UnreadOnly && !item.Unread {
+		return false
+	}
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, notificationType := range f.Types {
+		if item.NotificationType == notificationType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Filter returns the notifications of the response matching the filter
+func (r NotificationsResponse) Filter(filter NotificationFilter) []NotificationItem {
+	items := []NotificationItem{}
+	for _, notification := range r.Items {
+		for _, item := range notification.Items {
+			if filter.Match(item) {
+				items = append(items, item)
+			}
+		}
+	}
+
+	return items
+}
+
+// NotificationsPage returns a page of the notification feed of the user, starting at page 1.
+//
+// perPage is assumed to count notifications (NotificationItem) rather than the groups
+// of NotificationsResponse.Items, as the paging of the feed is not documented.
+func (c *Client) NotificationsPage(page int, perPage int) *HttpResponse[NotificationsResponse] {
+	return c.NotificationsPageCtx(context.Background(), page, perPage)
+}
+
+// NotificationsPageCtx is the context-aware variant of NotificationsPage
+func (c *Client) NotificationsPageCtx(ctx context.Context, page int, perPage int) *HttpResponse[NotificationsResponse] {
+	if page < 1 || perPage < 1 {
+		return invalid[NotificationsResponse](&ValidationError{Field: "page", Reason: "page and perPage must be greater than 0"})
+	}
+
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("per_page", strconv.Itoa(perPage))
+
+	return Do[NotificationsResponse](ctx, c, http.MethodGet, "api/notifications", query, nil)
+}
+
+// EachNotification walks the notification feed page by page, from the most recent,
+// calling fn once for each notification matching the filter. It stops at the last page
+// or at the first error returned by fn.
+//
+// A page holding fewer notifications than requested, across all of its groups, is the last.
+// Should the API count groups instead, a full page still holds at least as many
+// notifications, and the walk ends at the next page holding no unseen notification.
+func (c *Client) EachNotification(ctx context.Context, filter NotificationFilter, fn func(NotificationItem) error) error {
+	seen := map[int]bool{}
+	for page := 1; ; page++ {
+		resp := c.NotificationsPageCtx(ctx, page, defaultNotificationsPerPage)
+		if resp.Error != nil {
+			return resp.Error
+		}
+
+		items := resp.Response.Filter(NotificationFilter{})
+		unseen := false
+		for _, item := range items {
+			if seen[item.ID] {
+				continue
+			}
+			seen[item.ID] = true
+			unseen = true
+
+			if !filter.Match(item) {
+				continue
+			}
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+
+		// Stop at a short page, or at a page already seen if the API ignores paging
+		if !unseen || len(items) < defaultNotificationsPerPage {
+			return nil
+		}
+	}
+}
+
+// MarkNotificationRead marks a single notification as read.
+//
+// The endpoint is not part of the documented collection, which only lists the feed, and is assumed.
+func (c *Client) MarkNotificationRead(notificationId int) *HttpResponse[bool] {
+	return c.MarkNotificationReadCtx(context.Background(), notificationId)
+}
+
+// MarkNotificationReadCtx is the context-aware variant of MarkNotificationRead
+func (c *Client) MarkNotificationReadCtx(ctx context.Context, notificationId int) *HttpResponse[bool] {
+	return confirm(ctx, c, http.MethodPut, fmt.Sprintf("api/notifications/%d/read", notificationId), nil)
+}
+
+// MarkAllNotificationsRead marks every notification of the user as read.
+//
+// The endpoint is not part of the documented collection and is assumed.
+func (c *Client) MarkAllNotificationsRead() *HttpResponse[bool] {
+	return c.MarkAllNotificationsReadCtx(context.Background())
+}
+
+// MarkAllNotificationsReadCtx is the context-aware variant of MarkAllNotificationsRead
+func (c *Client) MarkAllNotificationsReadCtx(ctx context.Context) *HttpResponse[bool] {
+	return confirm(ctx, c, http.MethodPut, "api/notifications/read", nil)
+}
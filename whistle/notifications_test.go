@@ -0,0 +1,152 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/amattu2/go-whistle-wrapper/whistle"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestNotificationFilter(t *testing.T) {
+	t.Parallel()
+
+	resp := whistle.NotificationsResponse{Items: []whistle.Notification{{
+		Items: []whistle.NotificationItem{
+			{ID: 1, NotificationType: "battery", Unread: true},
+			{ID: 2, NotificationType: "location", Unread: true},
+			{ID: 3, NotificationType: "battery", Unread: false},
+		},
+	}}}
+
+	assert.Equal(t, 3, len(resp.Filter(whistle.NotificationFilter{})))
+	assert.Equal(t, 2, len(resp.Filter(whistle.NotificationFilter{UnreadOnly: true})))
+	assert.Equal(t, 1, resp.Filter(whistle.NotificationFilter{Types: []string{"battery"}, UnreadOnly: true})[0].ID)
+}
+
+func TestEachNotification(t *testing.T) {
+	t.Parallel()
+
+	// 30 notifications split into pages of 25, grouped by 4, every third one unread
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+
+		groups, items := []string{}, []string{}
+		for id := (page-1)*perPage + 1; id <= page*perPage && id <= 30; id++ {
+			items = append(items, fmt.Sprintf(`{"id":%d,"unread":%t}`, id, id%3 == 0))
+			if len(items) == 4 || id == page*perPage || id == 30 {
+				groups = append(groups, fmt.Sprintf(`{"items":[%s]}`, strings.Join(items, ",")))
+				items = []string{}
+			}
+		}
+		fmt.Fprintf(w, `{"items":[%s]}`, strings.Join(groups, ","))
+	}))
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+
+	var ids []int
+	err := client.EachNotification(context.Background(), whistle.NotificationFilter{UnreadOnly: true}, func(item whistle.NotificationItem) error {
+		ids = append(ids, item.ID)
+		return nil
+	})
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []int{3, 6, 9, 12, 15, 18, 21, 24, 27, 30}, ids)
+}
+
+func TestEachNotificationPagingIgnored(t *testing.T) {
+	t.Parallel()
+
+	// Every page is the same full page of 25 notifications
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		items := []string{}
+		for id := 1; id <= 25; id++ {
+			items = append(items, fmt.Sprintf(`{"items":[{"id":%d}]}`, id))
+		}
+		fmt.Fprintf(w, `{"items":[%s]}`, strings.Join(items, ","))
+	}))
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+
+	calls := 0
+	err := client.EachNotification(context.Background(), whistle.NotificationFilter{}, func(item whistle.NotificationItem) error {
+		calls++
+		return nil
+	})
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 25, calls)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestEachNotificationStop(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items":[{"items":[{"id":1},{"id":2}]}]}`))
+	}))
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+
+	stop := errors.New("stop")
+	err := client.EachNotification(context.Background(), whistle.NotificationFilter{}, func(item whistle.NotificationItem) error {
+		return stop
+	})
+
+	assert.Equal(t, stop, err)
+}
+
+func TestMarkNotificationRead(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusNoContent, "")
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+
+	assert.Equal(t, true, client.MarkNotificationRead(42).Response)
+	assert.Equal(t, http.MethodPut, last().Method)
+	assert.Equal(t, "/api/notifications/42/read", last().Path)
+
+	assert.Equal(t, true, client.MarkAllNotificationsRead().Response)
+	assert.Equal(t, "/api/notifications/read", last().Path)
+}
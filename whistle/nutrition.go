@@ -0,0 +1,152 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type PetTreatResponse struct {
+	Errors []Error  `json:"errors"`
+	Treat  PetTreat `json:"treat"`
+}
+
+type PetTreat struct {
+	ID         int     `json:"id"`
+	PetFoodId  int     `json:"pet_food_id"`
+	Name       string  `json:"name"`
+	Quantity   float64 `json:"quantity"`
+	Unit       string  `json:"unit"`
+	Calories   float64 `json:"calories"`
+	ConsumedAt string  `json:"consumed_at"`
+}
+
+// PetFoodPortionInput is the share of a food from PetFoods() in the diet of a pet
+type PetFoodPortionInput struct {
+	PetFoodId   int     `json:"pet_food_id"`
+	Percentage  float64 `json:"percentage"`             // Percentages of all portions must sum to 100
+	FoodPortion string  `json:"food_portion,omitempty"` // e.g. 1.5
+	Unit        string  `json:"unit,omitempty"`         // e.g. cups
+}
+
+// PetTreatInput describes a treat given to a pet
+type PetTreatInput struct {
+	PetFoodId  int     `json:"pet_food_id"` // See PetFoods("dog_treat")
+	Quantity   float64 `json:"quantity"`
+	Unit       string  `json:"unit,omitempty"`
+	ConsumedAt string  `json:"consumed_at,omitempty"` // RFC 3339, defaults to now
+}
+
+// validatePortions checks that each food is listed once and that the percentages sum to 100
+func validatePortions(portions []PetFoodPortionInput) error {
+	if len(portions) == 0 {
+		return &ValidationError{Field: "pet_food_portions", Reason: "at least one portion is required"}
+	}
+
+	total := 0.0
+	foods := map[int]bool{}
+	for _, portion := range portions {
+		if portion.PetFoodId <= 0 {
+			return &ValidationError{Field: "pet_food_id", Reason: "is required"}
+		}
+		if foods[portion.PetFoodId] {
+			return &ValidationError{Field: "pet_food_id", Reason: fmt.Sprintf("food %d is listed more than once", portion.PetFoodId)}
+		}
+		if portion.Percentage <= 0 || portion.Percentage > 100 {
+			return &ValidationError{Field: "percentage", Reason: "must be greater than 0 and at most 100"}
+		}
+
+		foods[portion.PetFoodId] = true
+		total += portion.Percentage
+	}
+
+	if math.Abs(total-100) > 0.01 {
+		return &ValidationError{Field: "percentage", Reason: fmt.Sprintf("percentages must sum to 100, got %g", total)}
+	}
+
+	return nil
+}
+
+// SetPetFood sets the primary food of a pet from the catalog (see PetFoods).
+//
+// The food is set through UpdatePet, whose endpoint is not part of the documented collection
+// and is assumed.
+func (c *Client) SetPetFood(petId string, petFoodId int) *HttpResponse[PetResponse] {
+	return c.SetPetFoodCtx(context.Background(), petId, petFoodId)
+}
+
+// SetPetFoodCtx is the context-aware variant of SetPetFood
+func (c *Client) SetPetFoodCtx(ctx context.Context, petId string, petFoodId int) *HttpResponse[PetResponse] {
+	if petFoodId <= 0 {
+		return invalid[PetResponse](&ValidationError{Field: "pet_food_id", Reason: "is required"})
+	}
+
+	return c.UpdatePetCtx(ctx, petId, PetUpdate{PetFoodID: &petFoodId})
+}
+
+// SetPetFoodPortions replaces the foods and portions of a pet's diet.
+//
+// Replacing the portions is not part of the documented collection, which only lists them,
+// and is assumed.
+func (c *Client) SetPetFoodPortions(petId string, portions []PetFoodPortionInput) *HttpResponse[PetFoodPortionsResponse] {
+	return c.SetPetFoodPortionsCtx(context.Background(), petId, portions)
+}
+
+// SetPetFoodPortionsCtx is the context-aware variant of SetPetFoodPortions
+func (c *Client) SetPetFoodPortionsCtx(ctx context.Context, petId string, portions []PetFoodPortionInput) *HttpResponse[PetFoodPortionsResponse] {
+	if err := validatePortions(portions); err != nil {
+		return invalid[PetFoodPortionsResponse](err)
+	}
+
+	return Do[PetFoodPortionsResponse](ctx, c, http.MethodPut, fmt.Sprintf("api/pets/%s/pet_food_portions", url.PathEscape(petId)), nil, map[string]any{
+		"pet_food_portions": portions,
+	})
+}
+
+// LogTreat records a treat given to a pet.
+//
+// The endpoint is not part of the documented collection and is assumed.
+func (c *Client) LogTreat(petId string, treat PetTreatInput) *HttpResponse[PetTreatResponse] {
+	return c.LogTreatCtx(context.Background(), petId, treat)
+}
+
+// LogTreatCtx is the context-aware variant of LogTreat
+func (c *Client) LogTreatCtx(ctx context.Context, petId string, treat PetTreatInput) *HttpResponse[PetTreatResponse] {
+	if treat.PetFoodId <= 0 {
+		return invalid[PetTreatResponse](&ValidationError{Field: "pet_food_id", Reason: "is required"})
+	}
+	if treat.Quantity <= 0 {
+		return invalid[PetTreatResponse](&ValidationError{Field: "quantity", Reason: "must be greater than 0"})
+	}
+	if treat.ConsumedAt != "" {
+		if _, err := time.Parse(time.RFC3339, treat.ConsumedAt); err != nil {
+			return invalid[PetTreatResponse](&ValidationError{Field: "consumed_at", Reason: "must be formatted as RFC 3339"})
+		}
+	}
+
+	return Do[PetTreatResponse](ctx, c, http.MethodPost, fmt.Sprintf("api/pets/%s/nutrition/treats", url.PathEscape(petId)), nil, map[string]any{"treat": treat})
+}
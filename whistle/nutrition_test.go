@@ -0,0 +1,109 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/amattu2/go-whistle-wrapper/whistle"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestSetPetFood(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusOK, `{"pet":{"id":123,"profile":{"pet_food":{"id":77}}}}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.SetPetFood("123", 77)
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, 77, resp.Response.Pet.Profile.PetFood.ID)
+	assert.Equal(t, map[string]any{"profile": map[string]any{"pet_food": map[string]any{"id": 77.0}}}, last().Body)
+}
+
+func TestSetPetFoodPortions(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusOK, `{"pet_food_portions":[{"pet_food_id":1,"percentage":75},{"pet_food_id":2,"percentage":25}]}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.SetPetFoodPortions("123", []whistle.PetFoodPortionInput{
+		{PetFoodId: 1, Percentage: 75, FoodPortion: "1.5", Unit: "cups"},
+		{PetFoodId: 2, Percentage: 25},
+	})
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, 2, len(resp.Response.PetFoodPortions))
+	assert.Equal(t, http.MethodPut, last().Method)
+	assert.Equal(t, "/api/pets/123/pet_food_portions", last().Path)
+	assert.Equal(t, map[string]any{
+		"pet_food_portions": []any{
+			map[string]any{"pet_food_id": 1.0, "percentage": 75.0, "food_portion": "1.5", "unit": "cups"},
+			map[string]any{"pet_food_id": 2.0, "percentage": 25.0},
+		},
+	}, last().Body)
+}
+
+func TestSetPetFoodPortionsInvalid(t *testing.T) {
+	t.Parallel()
+
+	client := whistle.InitializeBearer("abc123")
+	tests := [][]whistle.PetFoodPortionInput{
+		{},
+		{{PetFoodId: 1, Percentage: 60}, {PetFoodId: 2, Percentage: 30}},
+		{{PetFoodId: 1, Percentage: 50}, {PetFoodId: 1, Percentage: 50}},
+		{{PetFoodId: 1, Percentage: 120}, {PetFoodId: 2, Percentage: -20}},
+		{{Percentage: 100}},
+	}
+
+	for _, portions := range tests {
+		var validationErr *whistle.ValidationError
+		assert.Equal(t, true, errors.As(client.SetPetFoodPortions("123", portions).Error, &validationErr))
+	}
+}
+
+func TestLogTreat(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusCreated, `{"treat":{"id":3,"pet_food_id":9,"quantity":2}}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.LogTreat("123", whistle.PetTreatInput{PetFoodId: 9, Quantity: 2, ConsumedAt: "2026-10-18T08:00:00Z"})
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, 3, resp.Response.Treat.ID)
+	assert.Equal(t, http.MethodPost, last().Method)
+	assert.Equal(t, "/api/pets/123/nutrition/treats", last().Path)
+
+	var validationErr *whistle.ValidationError
+	assert.Equal(t, true, errors.As(client.LogTreat("123", whistle.PetTreatInput{PetFoodId: 9}).Error, &validationErr))
+	assert.Equal(t, true, errors.As(client.LogTreat("123", whistle.PetTreatInput{PetFoodId: 9, Quantity: 1, ConsumedAt: "today"}).Error, &validationErr))
+}
@@ -0,0 +1,346 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/amattu2/go-whistle-wrapper/utils"
+)
+
+// Option configures a Client created by New
+type Option func(c *Client) error
+
+// Config describes a client configuration file read by FromConfigFile
+type Config struct {
+	Credentials
+
+	// BaseURL is the API environment, a URL or one of "production" and "staging"
+	BaseURL        string           `json:"base_url"`
+	Timeout        string           `json:"timeout"`
+	UserAgent      string           `json:"user_agent"`
+	AcceptLanguage string           `json:"accept_language"`
+	Retry          *RetryConfig     `json:"retry"`
+	RateLimit      *RateLimitConfig `json:"rate_limit"`
+}
+
+// RetryConfig is the configuration file representation of a RetryPolicy.
+// Unset values keep the DefaultRetryPolicy values.
+type RetryConfig struct {
+	MaxAttempts    int    `json:"max_attempts"`
+	InitialBackoff string `json:"initial_backoff"`
+	MaxBackoff     string `json:"max_backoff"`
+}
+
+// RateLimitConfig is the configuration file representation of a RateLimiter
+type RateLimitConfig struct {
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	Burst             int     `json:"burst"`
+}
+
+// New creates a new client configured by the provided options.
+//
+// Returns ErrMissingCredentials if no usable combination of credentials is configured.
+func New(opts ...Option) (*Client, error) {
+	c := &Client{
+		Timeout:        defaultTimeout,
+		Env:            ProdEnv,
+		UserAgent:      defaultUserAgent,
+		AcceptLanguage: defaultAcceptLanguage,
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	hasLogin := c.email != "" && (c.password != "" || c.refreshToken != "")
+	if !hasLogin && c.bearer == "" && c.token == "" {
+		return nil, ErrMissingCredentials
+	}
+
+	return c, nil
+}
+
+// FromEnv creates a new client configured by environment variables, followed by opts.
+//
+// Supported variables: WHISTLE_EMAIL, WHISTLE_PASSWORD, WHISTLE_REFRESH_TOKEN,
+// WHISTLE_BEARER, WHISTLE_TOKEN, WHISTLE_ENV, WHISTLE_TIMEOUT, WHISTLE_USER_AGENT
+// and WHISTLE_ACCEPT_LANGUAGE
+func FromEnv(opts ...Option) (*Client, error) {
+	cfg := Config{
+		Credentials: Credentials{
+			Email:        utils.GetEnv("WHISTLE_EMAIL", ""),
+			Password:     utils.GetEnv("WHISTLE_PASSWORD", ""),
+			RefreshToken: utils.GetEnv("WHISTLE_REFRESH_TOKEN", ""),
+			Bearer:       utils.GetEnv("WHISTLE_BEARER", ""),
+			Token:        utils.GetEnv("WHISTLE_TOKEN", ""),
+		},
+		BaseURL:        utils.GetEnv("WHISTLE_ENV", ""),
+		Timeout:        utils.GetEnv("WHISTLE_TIMEOUT", ""),
+		UserAgent:      utils.GetEnv("WHISTLE_USER_AGENT", ""),
+		AcceptLanguage: utils.GetEnv("WHISTLE_ACCEPT_LANGUAGE", ""),
+	}
+
+	return New(append([]Option{WithConfig(cfg)}, opts...)...)
+}
+
+// FromConfigFile creates a new client configured by the JSON file at path, followed by opts
+func FromConfigFile(path string, opts ...Option) (*Client, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := Config{}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("whistle: invalid config file %s: %w", path, err)
+	}
+
+	return New(append([]Option{WithConfig(cfg)}, opts...)...)
+}
+
+// WithConfig applies the non-empty values of cfg
+func WithConfig(cfg Config) Option {
+	return func(c *Client) error {
+		opts := []Option{WithCredentials(cfg.Credentials)}
+
+		if cfg.BaseURL != "" {
+			opts = append(opts, WithBaseURL(cfg.BaseURL))
+		}
+		if cfg.Timeout != "" {
+			timeout := c.Timeout
+			if err := parseDuration(cfg.Timeout, &timeout); err != nil {
+				return err
+			}
+
+			opts = append(opts, WithTimeout(timeout))
+		}
+		if cfg.UserAgent != "" {
+			opts = append(opts, WithUserAgent(cfg.UserAgent))
+		}
+		if cfg.AcceptLanguage != "" {
+			opts = append(opts, WithAcceptLanguage(cfg.AcceptLanguage))
+		}
+		if cfg.Retry != nil {
+			policy, err := cfg.Retry.policy()
+			if err != nil {
+				return err
+			}
+
+			opts = append(opts, WithRetry(policy))
+		}
+		if cfg.RateLimit != nil {
+			opts = append(opts, WithRateLimit(cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.Burst))
+		}
+
+		for _, opt := range opts {
+			if err := opt(c); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// WithCredentials sets the non-empty credentials
+func WithCredentials(creds Credentials) Option {
+	return func(c *Client) error {
+		if creds.Email != "" {
+			c.email = creds.Email
+		}
+		if creds.Password != "" {
+			c.password = creds.Password
+		}
+		if creds.RefreshToken != "" {
+			c.refreshToken = creds.RefreshToken
+		}
+		if creds.Bearer != "" {
+			c.bearer = creds.Bearer
+		}
+		if creds.Token != "" {
+			c.token = creds.Token
+		}
+
+		return nil
+	}
+}
+
+// WithEmailPassword sets email and password credentials
+func WithEmailPassword(email string, password string) Option {
+	return WithCredentials(Credentials{Email: email, Password: password})
+}
+
+// WithRefreshToken sets email and refresh token credentials
+func WithRefreshToken(email string, refreshToken string) Option {
+	return WithCredentials(Credentials{Email: email, RefreshToken: refreshToken})
+}
+
+// WithBearer sets an existing HTTP bearer
+func WithBearer(bearer string) Option {
+	return WithCredentials(Credentials{Bearer: bearer})
+}
+
+// WithBaseURL sets the API environment. Accepts a URL, "production" or "staging".
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) error {
+		switch strings.ToLower(baseURL) {
+		case "prod", "production":
+			c.Env = ProdEnv
+		case "staging":
+			c.Env = StagingEnv
+		default:
+			if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+				return fmt.Errorf("whistle: invalid base URL %q", baseURL)
+			}
+
+			c.Env = strings.TrimRight(baseURL, "/")
+		}
+
+		return nil
+	}
+}
+
+// WithTimeout sets the request timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		c.Timeout = timeout
+		return nil
+	}
+}
+
+// WithPollInterval sets the delay between requests of helpers that wait for the API
+func WithPollInterval(interval time.Duration) Option {
+	return func(c *Client) error {
+		c.PollInterval = interval
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		c.UserAgent = userAgent
+		return nil
+	}
+}
+
+// WithAcceptLanguage sets the Accept-Language header
+func WithAcceptLanguage(language string) Option {
+	return func(c *Client) error {
+		c.AcceptLanguage = language
+		return nil
+	}
+}
+
+// WithRetry sets the retry policy of the client
+func WithRetry(policy *RetryPolicy) Option {
+	return func(c *Client) error {
+		c.Retry = policy
+		return nil
+	}
+}
+
+// WithRateLimit limits the client to requestsPerSecond, with bursts of up to burst requests
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return WithRateLimiter(NewRateLimiter(requestsPerSecond, burst))
+}
+
+// WithRateLimiter sets a rate limiter, which may be shared with other clients
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) error {
+		c.RateLimiter = limiter
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send requests
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) error {
+		c.HTTPClient = client
+		return nil
+	}
+}
+
+// WithTransport sets the transport used to send requests
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) error {
+		c.Transport = transport
+		return nil
+	}
+}
+
+// WithMiddleware appends middleware to the transport chain
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Client) error {
+		c.Middleware = append(c.Middleware, middleware...)
+		return nil
+	}
+}
+
+// WithTokenStore sets the store used to persist tokens between client instances
+func WithTokenStore(store TokenStore) Option {
+	return func(c *Client) error {
+		c.TokenStore = store
+		return nil
+	}
+}
+
+// policy converts the configuration into a RetryPolicy
+func (cfg *RetryConfig) policy() (*RetryPolicy, error) {
+	policy := DefaultRetryPolicy()
+	if cfg.MaxAttempts != 0 {
+		policy.MaxAttempts = cfg.MaxAttempts
+	}
+
+	if err := parseDuration(cfg.InitialBackoff, &policy.InitialBackoff); err != nil {
+		return nil, err
+	}
+	if err := parseDuration(cfg.MaxBackoff, &policy.MaxBackoff); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// parseDuration parses value into target, leaving target unchanged if value is empty
+func parseDuration(value string, target *time.Duration) error {
+	if value == "" {
+		return nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("whistle: invalid duration %q: %w", value, err)
+	}
+
+	*target = d
+
+	return nil
+}
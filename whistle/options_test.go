@@ -0,0 +1,129 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle_test
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/amattu2/go-whistle-wrapper/whistle"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestNewOptions(t *testing.T) {
+	t.Parallel()
+
+	var language string
+	client, err := whistle.New(
+		whistle.WithBearer("abc123"),
+		whistle.WithBaseURL("staging"),
+		whistle.WithTimeout(3*time.Second),
+		whistle.WithUserAgent("go-test"),
+		whistle.WithAcceptLanguage("fr-FR"),
+		whistle.WithRetry(whistle.NoRetry()),
+		whistle.WithRateLimit(10, 5),
+		whistle.WithTransport(whistle.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			language = r.Header.Get("Accept-Language")
+			return stubTransport(`{"breeds":[]}`)(r)
+		})),
+	)
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, whistle.StagingEnv, client.Env)
+	assert.Equal(t, 3*time.Second, client.Timeout)
+	assert.Equal(t, "go-test", client.UserAgent)
+	assert.Equal(t, 1, client.Retry.MaxAttempts)
+	assert.Equal(t, "abc123", client.Bearer())
+	assert.NotEqual(t, nil, client.RateLimiter)
+	assert.Equal(t, http.StatusOK, client.Breeds("dogs").StatusCode)
+	assert.Equal(t, "fr-FR", language)
+}
+
+func TestNewMissingCredentials(t *testing.T) {
+	t.Parallel()
+
+	_, err := whistle.New(whistle.WithTimeout(time.Second))
+
+	assert.Equal(t, true, errors.Is(err, whistle.ErrMissingCredentials))
+}
+
+func TestNewInvalidBaseURL(t *testing.T) {
+	t.Parallel()
+
+	_, err := whistle.New(whistle.WithBearer("abc123"), whistle.WithBaseURL("app.whistle.com"))
+
+	assert.NotEqual(t, nil, err)
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("WHISTLE_EMAIL", "abc@gmail.com")
+	t.Setenv("WHISTLE_PASSWORD", "xyz")
+	t.Setenv("WHISTLE_BEARER", "")
+	t.Setenv("WHISTLE_ENV", "https://example.com/")
+	t.Setenv("WHISTLE_TIMEOUT", "2s")
+
+	client, err := whistle.FromEnv(whistle.WithUserAgent("override"))
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "https://example.com", client.Env)
+	assert.Equal(t, 2*time.Second, client.Timeout)
+	assert.Equal(t, "override", client.UserAgent)
+}
+
+func TestFromConfigFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "whistle.json")
+	os.WriteFile(path, []byte(`{
+		"email": "abc@gmail.com",
+		"refresh_token": "refresh123",
+		"base_url": "production",
+		"timeout": "5s",
+		"retry": {"max_attempts": 4, "initial_backoff": "100ms"},
+		"rate_limit": {"requests_per_second": 2, "burst": 4}
+	}`), 0600)
+
+	client, err := whistle.FromConfigFile(path)
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, whistle.ProdEnv, client.Env)
+	assert.Equal(t, 5*time.Second, client.Timeout)
+	assert.Equal(t, "refresh123", client.RefreshToken())
+	assert.Equal(t, 4, client.Retry.MaxAttempts)
+	assert.Equal(t, 100*time.Millisecond, client.Retry.InitialBackoff)
+	assert.NotEqual(t, nil, client.RateLimiter)
+}
+
+func TestFromConfigFileInvalid(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "whistle.json")
+	os.WriteFile(path, []byte(`{"bearer": "abc123", "timeout": "soon"}`), 0600)
+
+	_, err := whistle.FromConfigFile(path)
+
+	assert.NotEqual(t, nil, err)
+}
@@ -22,11 +22,17 @@
 package whistle
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
+
+	// Embeds the time zone database, so that NewPet.TimeZoneName is validated on hosts without one
+	_ "time/tzdata"
 )
 
 type PetsResponse struct {
@@ -42,7 +48,7 @@ type Pet struct {
 	Name                 string            `json:"name"`
 	ProfilePhotoUrlSizes map[string]string `json:"profile_photo_url_sizes"`
 	RealtimeChannel      RealtimeChannel   `json:"realtime_channel"`
-	SubscriptionStatus   string            `json:"subscription_status"`
+	SubscriptionStatus   StringList        `json:"subscription_status"`
 	PartnerServiceStatus string            `json:"partner_service_status"`
 	Device               Device            `json:"device"`
 	ActivitySummary      ActivitySummary   `json:"activity_summary"`
@@ -65,9 +71,9 @@ type ActivitySummary struct {
 }
 
 type ActivityGoal struct {
-	Minutes   int           `json:"minutes"`
-	StartedAt string        `json:"started_at"`
-	TimeZone  time.Location `json:"time_zone"`
+	Minutes   int    `json:"minutes"`
+	StartedAt string `json:"started_at"`
+	TimeZone  string `json:"time_zone"`
 }
 
 type PetProfile struct {
@@ -85,12 +91,172 @@ type PetProfile struct {
 	PetFood                    PetFood `json:"pet_food"`
 }
 
+const (
+	WeightPounds    = "pounds"
+	WeightKilograms = "kilograms"
+)
+
+const (
+	SpeciesDog = "dog"
+	SpeciesCat = "cat"
+)
+
+// NewPet describes a pet to be created with CreatePet
+type NewPet struct {
+	Name         string
+	Species      string // SpeciesDog or SpeciesCat
+	BreedID      int    // See Breeds()
+	DateOfBirth  string // YYYY-MM-DD
+	Weight       float64
+	WeightType   string // WeightPounds (default) or WeightKilograms
+	TimeZoneName string // IANA time zone name, e.g. America/New_York
+}
+
+// MarshalJSON encodes the pet in the format documented for POST api/pets
+func (p NewPet) MarshalJSON() ([]byte, error) {
+	weightType := p.WeightType
+	if weightType == "" {
+		weightType = WeightPounds
+	}
+
+	type profile struct {
+		Species      string       `json:"species"`
+		Breed        petReference `json:"breed"`
+		DateOfBirth  string       `json:"date_of_birth"`
+		Weight       float64      `json:"weight"`
+		WeightType   string       `json:"weight_type"`
+		TimeZoneName string       `json:"time_zone_name,omitempty"`
+	}
+
+	return json.Marshal(struct {
+		Name    string  `json:"name"`
+		Profile profile `json:"profile"`
+	}{
+		Name: p.Name,
+		Profile: profile{
+			Species:      p.Species,
+			Breed:        petReference{ID: p.BreedID},
+			DateOfBirth:  p.DateOfBirth,
+			Weight:       p.Weight,
+			WeightType:   weightType,
+			TimeZoneName: p.TimeZoneName,
+		},
+	})
+}
+
+// validate checks that the required fields are set and valid
+func (p NewPet) validate() error {
+	if p.Species != SpeciesDog && p.Species != SpeciesCat {
+		return &ValidationError{Field: "species", Reason: fmt.Sprintf("must be %q or %q", SpeciesDog, SpeciesCat)}
+	}
+	if p.BreedID <= 0 {
+		return &ValidationError{Field: "breed_id", Reason: "is required"}
+	}
+	if birth, err := time.Parse("2006-01-02", p.DateOfBirth); err == nil && birth.After(time.Now()) {
+		return &ValidationError{Field: "date_of_birth", Reason: "must not be in the future"}
+	}
+	if p.TimeZoneName != "" {
+		if _, err := time.LoadLocation(p.TimeZoneName); err != nil || p.TimeZoneName == "Local" {
+			return &ValidationError{Field: "time_zone_name", Reason: fmt.Sprintf("unknown time zone %q", p.TimeZoneName)}
+		}
+	}
+
+	update := PetUpdate{Name: &p.Name, Weight: &p.Weight, DateOfBirth: &p.DateOfBirth}
+	if p.WeightType != "" {
+		update.WeightType = &p.WeightType
+	}
+
+	return update.validate()
+}
+
+// PetUpdate is a partial update of a pet and its profile. Only the fields that are set are sent.
+type PetUpdate struct {
+	Name               *string
+	Weight             *float64
+	WeightType         *string // WeightPounds or WeightKilograms
+	DateOfBirth        *string // YYYY-MM-DD
+	BreedID            *int    // See Breeds()
+	IsFixed            *bool
+	BodyConditionScore *float64 // 1 to 9
+	PetFoodID          *int     // See PetFoods()
+}
+
+// petReference is a breed or food of a pet profile, referenced by ID as sent to the API
+type petReference struct {
+	ID int `json:"id"`
+}
+
+type petProfileUpdate struct {
+	Weight             *float64      `json:"weight,omitempty"`
+	WeightType         *string       `json:"weight_type,omitempty"`
+	DateOfBirth        *string       `json:"date_of_birth,omitempty"`
+	Breed              *petReference `json:"breed,omitempty"`
+	IsFixed            *bool         `json:"is_fixed,omitempty"`
+	BodyConditionScore *float64      `json:"body_condition_score,omitempty"`
+	PetFood            *petReference `json:"pet_food,omitempty"`
+}
+
+// MarshalJSON encodes the update in the flat format of the api/pets body, as CreatePet does
+func (u PetUpdate) MarshalJSON() ([]byte, error) {
+	pet := struct {
+		Name    *string           `json:"name,omitempty"`
+		Profile *petProfileUpdate `json:"profile,omitempty"`
+	}{Name: u.Name}
+
+	profile := petProfileUpdate{
+		Weight:             u.Weight,
+		WeightType:         u.WeightType,
+		DateOfBirth:        u.DateOfBirth,
+		IsFixed:            u.IsFixed,
+		BodyConditionScore: u.BodyConditionScore,
+	}
+	if u.BreedID != nil {
+		profile.Breed = &petReference{ID: *u.BreedID}
+	}
+	if u.PetFoodID != nil {
+		profile.PetFood = &petReference{ID: *u.PetFoodID}
+	}
+	if profile != (petProfileUpdate{}) {
+		pet.Profile = &profile
+	}
+
+	return json.Marshal(pet)
+}
+
+// validate checks the fields that are set before sending the update
+func (u PetUpdate) validate() error {
+	if u == (PetUpdate{}) {
+		return &ValidationError{Field: "update", Reason: "no fields are set"}
+	}
+	if u.Name != nil && strings.TrimSpace(*u.Name) == "" {
+		return &ValidationError{Field: "name", Reason: "must not be empty"}
+	}
+	if u.Weight != nil && *u.Weight <= 0 {
+		return &ValidationError{Field: "weight", Reason: "must be greater than 0"}
+	}
+	if u.WeightType != nil && *u.WeightType != WeightPounds && *u.WeightType != WeightKilograms {
+		return &ValidationError{Field: "weight_type", Reason: fmt.Sprintf("must be %q or %q", WeightPounds, WeightKilograms)}
+	}
+	if u.DateOfBirth != nil {
+		if _, err := time.Parse("2006-01-02", *u.DateOfBirth); err != nil {
+			return &ValidationError{Field: "date_of_birth", Reason: "must be formatted as YYYY-MM-DD"}
+		}
+	}
+	if u.BodyConditionScore != nil && (*u.BodyConditionScore < 1 || *u.BodyConditionScore > 9) {
+		return &ValidationError{Field: "body_condition_score", Reason: "must be between 1 and 9"}
+	}
+
+	return nil
+}
+
 type TransfersResponse struct {
 	Transfers []TransferPet `json:"transfers"`
 }
 
 type TransferPet struct {
-	Pet Pet `json:"pet"`
+	ID     int    `json:"id"`
+	Status string `json:"status"`
+	Pet    Pet    `json:"pet"`
 }
 
 type PetOwnersResponse struct {
@@ -213,7 +379,7 @@ type DailyItem struct {
 	Data      []DailyItemData `json:"data"`
 	StartTime string          `json:"start_time"`
 	EndTime   string          `json:"end_time"`
-	TimeZone  time.Location   `json:"time_zone"`
+	TimeZone  string          `json:"time_zone"`
 }
 
 type DailyItemData struct {
@@ -288,442 +454,257 @@ type PetFoodPortion struct {
 	FoodPortion string  `json:"food_portion"`
 }
 
+type PetTasksResponse struct {
+	Errors []Error   `json:"errors"`
+	Tasks  []PetTask `json:"tasks"`
+}
+
 type PetTaskResponse struct {
 	Errors []Error `json:"errors"`
+	Task   PetTask `json:"task"`
 }
 
 type PetTaskOccurrenceResponse struct {
-	Errors          []Error   `json:"errors"`
-	PetId           int       `json:"pet_id"`
-	TaskOccurrences []PetTask `json:"task_occurrences"`
+	Errors          []Error             `json:"errors"`
+	PetId           int                 `json:"pet_id"`
+	TaskOccurrences []PetTaskOccurrence `json:"task_occurrences"`
+}
+
+type PetTaskOccurrenceUpdateResponse struct {
+	Errors         []Error           `json:"errors"`
+	TaskOccurrence PetTaskOccurrence `json:"task_occurrence"`
 }
 
 type PetTask struct {
+	ID               int               `json:"id"`
+	Title            string            `json:"title"`
+	TaskType         string            `json:"task_type"`
+	Notes            string            `json:"notes"`
+	StartDate        string            `json:"start_date"`
+	DueTime          string            `json:"due_time"`
+	TimeZoneName     string            `json:"time_zone_name"`
+	Recurrence       PetTaskRecurrence `json:"recurrence"`
+	NextOccurrenceAt string            `json:"next_occurrence_at"`
+	CreatedAt        string            `json:"created_at"`
+	UpdatedAt        string            `json:"updated_at"`
+}
+
+type PetTaskRecurrence struct {
+	Frequency  string   `json:"frequency"`
+	Interval   int      `json:"interval"`
+	DaysOfWeek []string `json:"days_of_week"`
+}
+
+type PetTaskOccurrence struct {
+	ID          int     `json:"id"`
+	TaskId      int     `json:"task_id"`
+	DueAt       string  `json:"due_at"`
+	Status      string  `json:"status"`
+	CompletedAt string  `json:"completed_at"`
+	Task        PetTask `json:"task"`
 }
 
 // Pets returns a list of pets owned by the user.
-func (c Client) Pets() *HttpResponse[PetsResponse] {
-	resp, err := c.get("api/pets", nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[PetsResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := PetsResponse{}
-	json.Unmarshal(body, &result)
+func (c *Client) Pets() *HttpResponse[PetsResponse] {
+	return c.PetsCtx(context.Background())
+}
 
-	return &HttpResponse[PetsResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// PetsCtx is the context-aware variant of Pets
+func (c *Client) PetsCtx(ctx context.Context) *HttpResponse[PetsResponse] {
+	return Do[PetsResponse](ctx, c, http.MethodGet, "api/pets", nil, nil)
 }
 
 // Transfers returns a list of pet transfers
-func (c Client) PetTransfers() *HttpResponse[TransfersResponse] {
-	resp, err := c.get("api/pets/transfers", nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[TransfersResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := TransfersResponse{}
-	json.Unmarshal(body, &result)
+func (c *Client) PetTransfers() *HttpResponse[TransfersResponse] {
+	return c.PetTransfersCtx(context.Background())
+}
 
-	return &HttpResponse[TransfersResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// PetTransfersCtx is the context-aware variant of PetTransfers
+func (c *Client) PetTransfersCtx(ctx context.Context) *HttpResponse[TransfersResponse] {
+	return Do[TransfersResponse](ctx, c, http.MethodGet, "api/pets/transfers", nil, nil)
 }
 
 // Pet returns detailed information about a user's pet.
-func (c Client) Pet(petId string) *HttpResponse[PetResponse] {
-	resp, err := c.get("api/pets/"+petId, nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[PetResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
+func (c *Client) Pet(petId string) *HttpResponse[PetResponse] {
+	return c.PetCtx(context.Background(), petId)
+}
 
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := PetResponse{}
-	json.Unmarshal(body, &result)
+// PetCtx is the context-aware variant of Pet
+func (c *Client) PetCtx(ctx context.Context, petId string) *HttpResponse[PetResponse] {
+	return Do[PetResponse](ctx, c, http.MethodGet, "api/pets/"+url.PathEscape(petId), nil, nil)
+}
 
-	return &HttpResponse[PetResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// CreatePet creates a new pet owned by the user, returning the created pet and its ID.
+func (c *Client) CreatePet(pet NewPet) *HttpResponse[PetResponse] {
+	return c.CreatePetCtx(context.Background(), pet)
 }
 
-// PetOwners returns a list of users who own a pet.
-func (c Client) PetOwners(petId string) *HttpResponse[PetOwnersResponse] {
-	resp, err := c.get("api/pets/"+petId+"/owners", nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[PetOwnersResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
+// CreatePetCtx is the context-aware variant of CreatePet
+func (c *Client) CreatePetCtx(ctx context.Context, pet NewPet) *HttpResponse[PetResponse] {
+	if err := pet.validate(); err != nil {
+		return invalid[PetResponse](err)
 	}
 
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := PetOwnersResponse{}
-	json.Unmarshal(body, &result)
+	return Do[PetResponse](ctx, c, http.MethodPost, "api/pets", nil, pet)
+}
 
-	return &HttpResponse[PetOwnersResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// UpdatePet updates the name and profile of a pet, returning the updated pet.
+//
+// Only the fields set in the update are changed.
+//
+// Updating a pet is not part of the documented collection, which only covers reading and
+// creating pets, and is assumed to accept the body of CreatePet.
+func (c *Client) UpdatePet(petId string, update PetUpdate) *HttpResponse[PetResponse] {
+	return c.UpdatePetCtx(context.Background(), petId, update)
 }
 
-// PetWhereabouts returns information about a pet's location history.
-func (c Client) PetWhereabouts(petId string, startDate string, endDate string) *HttpResponse[PetWhereaboutsResponse] {
-	resp, err := c.get(fmt.Sprintf("api/pets/%s/whereabouts?start_time=%s&end_time=%s", petId, startDate, endDate), nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[PetWhereaboutsResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
+// UpdatePetCtx is the context-aware variant of UpdatePet
+func (c *Client) UpdatePetCtx(ctx context.Context, petId string, update PetUpdate) *HttpResponse[PetResponse] {
+	if err := update.validate(); err != nil {
+		return invalid[PetResponse](err)
 	}
 
-	defer resp.Body.Close()
+	return Do[PetResponse](ctx, c, http.MethodPut, "api/pets/"+url.PathEscape(petId), nil, update)
+}
 
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := PetWhereaboutsResponse{}
-	json.Unmarshal(body, &result)
+// PetOwners returns a list of users who own a pet.
+func (c *Client) PetOwners(petId string) *HttpResponse[PetOwnersResponse] {
+	return c.PetOwnersCtx(context.Background(), petId)
+}
 
-	return &HttpResponse[PetWhereaboutsResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// PetOwnersCtx is the context-aware variant of PetOwners
+func (c *Client) PetOwnersCtx(ctx context.Context, petId string) *HttpResponse[PetOwnersResponse] {
+	return Do[PetOwnersResponse](ctx, c, http.MethodGet, "api/pets/"+url.PathEscape(petId)+"/owners", nil, nil)
 }
 
-// PetLocationsRecent provides a list of recent tracking locations for a pet
-func (c Client) PetLocationsRecent(petId string) *HttpResponse[PetLocationsRecentResponse] {
-	resp, err := c.get("api/pets/"+petId+"/locations/recent_trackings", nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[PetLocationsRecentResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
+// PetWhereabouts returns information about a pet's location history.
+func (c *Client) PetWhereabouts(petId string, startDate string, endDate string) *HttpResponse[PetWhereaboutsResponse] {
+	return c.PetWhereaboutsCtx(context.Background(), petId, startDate, endDate)
+}
 
-	defer resp.Body.Close()
+// PetWhereaboutsCtx is the context-aware variant of PetWhereabouts
+func (c *Client) PetWhereaboutsCtx(ctx context.Context, petId string, startDate string, endDate string) *HttpResponse[PetWhereaboutsResponse] {
+	return Do[PetWhereaboutsResponse](ctx, c, http.MethodGet, fmt.Sprintf("api/pets/%s/whereabouts", url.PathEscape(petId)), url.Values{"start_time": {startDate}, "end_time": {endDate}}, nil)
+}
 
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := PetLocationsRecentResponse{}
-	json.Unmarshal(body, &result)
+// PetLocationsRecent provides a list of recent tracking locations for a pet
+func (c *Client) PetLocationsRecent(petId string) *HttpResponse[PetLocationsRecentResponse] {
+	return c.PetLocationsRecentCtx(context.Background(), petId)
+}
 
-	return &HttpResponse[PetLocationsRecentResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// PetLocationsRecentCtx is the context-aware variant of PetLocationsRecent
+func (c *Client) PetLocationsRecentCtx(ctx context.Context, petId string) *HttpResponse[PetLocationsRecentResponse] {
+	return Do[PetLocationsRecentResponse](ctx, c, http.MethodGet, "api/pets/"+url.PathEscape(petId)+"/locations/recent_trackings", nil, nil)
 }
 
 // PetAchievements returns a list of achievements for a pet.
-func (c Client) PetAchievements(petId string) *HttpResponse[PetAchievementsResponse] {
-	resp, err := c.get("api/pets/"+petId+"/achievements", nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[PetAchievementsResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := PetAchievementsResponse{}
-	json.Unmarshal(body, &result)
+func (c *Client) PetAchievements(petId string) *HttpResponse[PetAchievementsResponse] {
+	return c.PetAchievementsCtx(context.Background(), petId)
+}
 
-	return &HttpResponse[PetAchievementsResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// PetAchievementsCtx is the context-aware variant of PetAchievements
+func (c *Client) PetAchievementsCtx(ctx context.Context, petId string) *HttpResponse[PetAchievementsResponse] {
+	return Do[PetAchievementsResponse](ctx, c, http.MethodGet, "api/pets/"+url.PathEscape(petId)+"/achievements", nil, nil)
 }
 
 // PetStatistics returns statistics statistical insights about a pet.
-func (c Client) PetStatistics(petId string) *HttpResponse[PetStatisticsResponse] {
-	resp, err := c.get("api/pets/"+petId+"/stats", nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[PetStatisticsResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := PetStatisticsResponse{}
-	json.Unmarshal(body, &result)
+func (c *Client) PetStatistics(petId string) *HttpResponse[PetStatisticsResponse] {
+	return c.PetStatisticsCtx(context.Background(), petId)
+}
 
-	return &HttpResponse[PetStatisticsResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// PetStatisticsCtx is the context-aware variant of PetStatistics
+func (c *Client) PetStatisticsCtx(ctx context.Context, petId string) *HttpResponse[PetStatisticsResponse] {
+	return Do[PetStatisticsResponse](ctx, c, http.MethodGet, "api/pets/"+url.PathEscape(petId)+"/stats", nil, nil)
 }
 
 // PetDailies returns a list of daily activities for a pet.
-func (c Client) PetDailies(petId string) *HttpResponse[PetDailiesResponse] {
-	resp, err := c.get("api/pets/"+petId+"/dailies", nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[PetDailiesResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := PetDailiesResponse{}
-	json.Unmarshal(body, &result)
+func (c *Client) PetDailies(petId string) *HttpResponse[PetDailiesResponse] {
+	return c.PetDailiesCtx(context.Background(), petId)
+}
 
-	return &HttpResponse[PetDailiesResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// PetDailiesCtx is the context-aware variant of PetDailies
+func (c *Client) PetDailiesCtx(ctx context.Context, petId string) *HttpResponse[PetDailiesResponse] {
+	return Do[PetDailiesResponse](ctx, c, http.MethodGet, "api/pets/"+url.PathEscape(petId)+"/dailies", nil, nil)
 }
 
 // PetDaily returns information about a pet's daily activity on the specified day.
-func (c Client) PetDaily(petId string, dailyId string) *HttpResponse[PetDailyResponse] {
-	resp, err := c.get("api/pets/"+petId+"/dailies/"+dailyId, nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[PetDailyResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := PetDailyResponse{}
-	json.Unmarshal(body, &result)
+func (c *Client) PetDaily(petId string, dailyId string) *HttpResponse[PetDailyResponse] {
+	return c.PetDailyCtx(context.Background(), petId, dailyId)
+}
 
-	return &HttpResponse[PetDailyResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// PetDailyCtx is the context-aware variant of PetDaily
+func (c *Client) PetDailyCtx(ctx context.Context, petId string, dailyId string) *HttpResponse[PetDailyResponse] {
+	return Do[PetDailyResponse](ctx, c, http.MethodGet, "api/pets/"+url.PathEscape(petId)+"/dailies/"+url.PathEscape(dailyId), nil, nil)
 }
 
 // PetDailyItems returns a item breakdown of a pet's daily activity on the specified day.
-func (c Client) PetDailyItems(petId string, dailyId string) *HttpResponse[PetDailyItemsResponse] {
-	resp, err := c.get("api/pets/"+petId+"/dailies/"+dailyId+"/daily_items", nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[PetDailyItemsResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := PetDailyItemsResponse{}
-	json.Unmarshal(body, &result)
+func (c *Client) PetDailyItems(petId string, dailyId string) *HttpResponse[PetDailyItemsResponse] {
+	return c.PetDailyItemsCtx(context.Background(), petId, dailyId)
+}
 
-	return &HttpResponse[PetDailyItemsResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// PetDailyItemsCtx is the context-aware variant of PetDailyItems
+func (c *Client) PetDailyItemsCtx(ctx context.Context, petId string, dailyId string) *HttpResponse[PetDailyItemsResponse] {
+	return Do[PetDailyItemsResponse](ctx, c, http.MethodGet, "api/pets/"+url.PathEscape(petId)+"/dailies/"+url.PathEscape(dailyId)+"/daily_items", nil, nil)
 }
 
 // PetHealthTrends returns health trend information about a pet.
-func (c Client) PetHealthTrends(petId string) *HttpResponse[PetHealthTrendsResponse] {
-	resp, err := c.get("api/pets/"+petId+"/health/trends", nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[PetHealthTrendsResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := PetHealthTrendsResponse{}
-	json.Unmarshal(body, &result)
+func (c *Client) PetHealthTrends(petId string) *HttpResponse[PetHealthTrendsResponse] {
+	return c.PetHealthTrendsCtx(context.Background(), petId)
+}
 
-	return &HttpResponse[PetHealthTrendsResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// PetHealthTrendsCtx is the context-aware variant of PetHealthTrends
+func (c *Client) PetHealthTrendsCtx(ctx context.Context, petId string) *HttpResponse[PetHealthTrendsResponse] {
+	return Do[PetHealthTrendsResponse](ctx, c, http.MethodGet, "api/pets/"+url.PathEscape(petId)+"/health/trends", nil, nil)
 }
 
 // PetHealthGraphs returns graphical information about a pet's health based on the specified trend
-func (c Client) PetHealthGraphs(petId string, trend string, days int) *HttpResponse[PetHealthGraphsResponse] {
-	resp, err := c.get(fmt.Sprintf("api/pets/%s/health/graphs/%s?num_of_days=%d", petId, trend, days), nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[PetHealthGraphsResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := PetHealthGraphsResponse{}
-	json.Unmarshal(body, &result)
+func (c *Client) PetHealthGraphs(petId string, trend string, days int) *HttpResponse[PetHealthGraphsResponse] {
+	return c.PetHealthGraphsCtx(context.Background(), petId, trend, days)
+}
 
-	return &HttpResponse[PetHealthGraphsResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// PetHealthGraphsCtx is the context-aware variant of PetHealthGraphs
+func (c *Client) PetHealthGraphsCtx(ctx context.Context, petId string, trend string, days int) *HttpResponse[PetHealthGraphsResponse] {
+	return Do[PetHealthGraphsResponse](ctx, c, http.MethodGet, fmt.Sprintf("api/pets/%s/health/graphs/%s", url.PathEscape(petId), url.PathEscape(trend)), url.Values{"num_of_days": {strconv.Itoa(days)}}, nil)
 }
 
 // PetNutritionPortions returns information about suggested food portions for a pet.
-func (c Client) PetNutritionPortions(petId string) *HttpResponse[PetNutritionPortionsResponse] {
-	resp, err := c.get("api/pets/"+petId+"/nutrition/v2/suggested_portions", nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[PetNutritionPortionsResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := PetNutritionPortionsResponse{}
-	json.Unmarshal(body, &result)
+func (c *Client) PetNutritionPortions(petId string) *HttpResponse[PetNutritionPortionsResponse] {
+	return c.PetNutritionPortionsCtx(context.Background(), petId)
+}
 
-	return &HttpResponse[PetNutritionPortionsResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// PetNutritionPortionsCtx is the context-aware variant of PetNutritionPortions
+func (c *Client) PetNutritionPortionsCtx(ctx context.Context, petId string) *HttpResponse[PetNutritionPortionsResponse] {
+	return Do[PetNutritionPortionsResponse](ctx, c, http.MethodGet, "api/pets/"+url.PathEscape(petId)+"/nutrition/v2/suggested_portions", nil, nil)
 }
 
 // PetFoodPortions returns information about food portions for a pet.
 //
 // Deprecated: Use PetNutritionPortions instead
-func (c Client) PetFoodPortions(petId string) *HttpResponse[PetFoodPortionsResponse] {
-	resp, err := c.get("api/pets/"+petId+"/pet_food_portions", nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[PetFoodPortionsResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := PetFoodPortionsResponse{}
-	json.Unmarshal(body, &result)
+func (c *Client) PetFoodPortions(petId string) *HttpResponse[PetFoodPortionsResponse] {
+	return c.PetFoodPortionsCtx(context.Background(), petId)
+}
 
-	return &HttpResponse[PetFoodPortionsResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// PetFoodPortionsCtx is the context-aware variant of PetFoodPortions
+func (c *Client) PetFoodPortionsCtx(ctx context.Context, petId string) *HttpResponse[PetFoodPortionsResponse] {
+	return Do[PetFoodPortionsResponse](ctx, c, http.MethodGet, "api/pets/"+url.PathEscape(petId)+"/pet_food_portions", nil, nil)
 }
 
 // PetTask returns detailed information about the specified task for a pet.
-func (c Client) PetTask(petId string, taskId string) *HttpResponse[PetTaskResponse] {
-	resp, err := c.get("api/pets/"+petId+"/tasks/"+taskId, nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[PetTaskResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := PetTaskResponse{}
-	json.Unmarshal(body, &result)
+func (c *Client) PetTask(petId string, taskId string) *HttpResponse[PetTaskResponse] {
+	return c.PetTaskCtx(context.Background(), petId, taskId)
+}
 
-	return &HttpResponse[PetTaskResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// PetTaskCtx is the context-aware variant of PetTask
+func (c *Client) PetTaskCtx(ctx context.Context, petId string, taskId string) *HttpResponse[PetTaskResponse] {
+	return Do[PetTaskResponse](ctx, c, http.MethodGet, "api/pets/"+url.PathEscape(petId)+"/tasks/"+url.PathEscape(taskId), nil, nil)
 }
 
 // PetTaskOccurrence returns information about the occurrence type (e.g. incomplete)
-func (c Client) PetTaskOccurrence(petId string, occurrenceType string) *HttpResponse[PetTaskOccurrenceResponse] {
-	resp, err := c.get("api/pets/"+petId+"/task_occurrences/?type="+occurrenceType, nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[PetTaskOccurrenceResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := PetTaskOccurrenceResponse{}
-	json.Unmarshal(body, &result)
+func (c *Client) PetTaskOccurrence(petId string, occurrenceType string) *HttpResponse[PetTaskOccurrenceResponse] {
+	return c.PetTaskOccurrenceCtx(context.Background(), petId, occurrenceType)
+}
 
-	return &HttpResponse[PetTaskOccurrenceResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// PetTaskOccurrenceCtx is the context-aware variant of PetTaskOccurrence
+func (c *Client) PetTaskOccurrenceCtx(ctx context.Context, petId string, occurrenceType string) *HttpResponse[PetTaskOccurrenceResponse] {
+	return Do[PetTaskOccurrenceResponse](ctx, c, http.MethodGet, "api/pets/"+url.PathEscape(petId)+"/task_occurrences/", url.Values{"type": {occurrenceType}}, nil)
 }
@@ -22,10 +22,12 @@
 package whistle_test
 
 import (
+	"errors"
 	"net/http"
 	"testing"
 
 	"github.com/amattu2/go-whistle-wrapper/utils"
+	"github.com/amattu2/go-whistle-wrapper/whistle"
 	"github.com/go-playground/assert/v2"
 )
 
@@ -74,6 +76,117 @@ func TestPet(t *testing.T) {
 	assert.NotEqual(t, "", r.Response.Pet.Name)
 }
 
+func TestCreatePet(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusCreated, `{"pet":{"id":456,"name":"Barker"}}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.CreatePet(whistle.NewPet{
+		Name:         "Barker",
+		Species:      whistle.SpeciesDog,
+		BreedID:      12,
+		DateOfBirth:  "2020-05-01",
+		Weight:       30,
+		TimeZoneName: "America/Chicago",
+	})
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, 456, resp.Response.Pet.ID)
+	assert.Equal(t, http.MethodPost, last().Method)
+	assert.Equal(t, "/api/pets", last().Path)
+	assert.Equal(t, map[string]any{
+		"name": "Barker",
+		"profile": map[string]any{
+			"species":        "dog",
+			"breed":          map[string]any{"id": 12.0},
+			"date_of_birth":  "2020-05-01",
+			"weight":         30.0,
+			"weight_type":    "pounds",
+			"time_zone_name": "America/Chicago",
+		},
+	}, last().Body)
+}
+
+func TestCreatePetInvalid(t *testing.T) {
+	t.Parallel()
+
+	client := whistle.InitializeBearer("abc123")
+	valid := whistle.NewPet{Name: "Barker", Species: whistle.SpeciesDog, BreedID: 12, DateOfBirth: "2020-05-01", Weight: 30}
+
+	tests := map[string]func(p *whistle.NewPet){
+		"name":           func(p *whistle.NewPet) { p.Name = "" },
+		"species":        func(p *whistle.NewPet) { p.Species = "rhino" },
+		"breed_id":       func(p *whistle.NewPet) { p.BreedID = 0 },
+		"date_of_birth":  func(p *whistle.NewPet) { p.DateOfBirth = "2999-01-01" },
+		"weight":         func(p *whistle.NewPet) { p.Weight = -1 },
+		"weight_type":    func(p *whistle.NewPet) { p.WeightType = "stone" },
+		"time_zone_name": func(p *whistle.NewPet) { p.TimeZoneName = "Mars/Olympus_Mons" },
+	}
+
+	for field, modify := range tests {
+		var validationErr *whistle.ValidationError
+		pet := valid
+		modify(&pet)
+		resp := client.CreatePet(pet)
+
+		assert.Equal(t, true, errors.As(resp.Error, &validationErr))
+		assert.Equal(t, field, validationErr.Field)
+	}
+}
+
+func TestUpdatePet(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusOK, `{"pet":{"id":123,"name":"Barker","profile":{"weight":42.5}}}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.UpdatePet("123", whistle.PetUpdate{
+		Name:    whistle.Ptr("Barker"),
+		Weight:  whistle.Ptr(42.5),
+		BreedID: whistle.Ptr(18),
+	})
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, 42.5, resp.Response.Pet.Profile.Weight)
+	assert.Equal(t, http.MethodPut, last().Method)
+	assert.Equal(t, "/api/pets/123", last().Path)
+	assert.Equal(t, map[string]any{
+		"name": "Barker",
+		"profile": map[string]any{
+			"weight": 42.5,
+			"breed":  map[string]any{"id": 18.0},
+		},
+	}, last().Body)
+}
+
+func TestUpdatePetInvalid(t *testing.T) {
+	t.Parallel()
+
+	client := whistle.InitializeBearer("abc123")
+	updates := []whistle.PetUpdate{
+		{},
+		{Name: whistle.Ptr(" ")},
+		{Weight: whistle.Ptr(0.0)},
+		{WeightType: whistle.Ptr("stone")},
+		{DateOfBirth: whistle.Ptr("01/02/2020")},
+		{BodyConditionScore: whistle.Ptr(10.0)},
+	}
+
+	for _, update := range updates {
+		var validationErr *whistle.ValidationError
+		resp := client.UpdatePet("123", update)
+
+		assert.Equal(t, true, errors.As(resp.Error, &validationErr))
+		assert.Equal(t, nil, resp.Raw)
+	}
+}
+
 func TestPetOwners(t *testing.T) {
 	t.Parallel()
 
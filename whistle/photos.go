@@ -0,0 +1,182 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+)
+
+// MaxPhotoSize is the largest profile photo accepted by UploadPetPhoto and UploadUserPhoto, in bytes
+const MaxPhotoSize = 10 << 20
+
+// photoTypes are the content types accepted for profile photos
+var photoTypes = []string{"image/jpeg", "image/png"}
+
+// readPhoto reads a profile photo, checking its size and that its content matches the content type
+func readPhoto(photo io.Reader, contentType string) ([]byte, error) {
+	accepted := false
+	for _, photoType := range photoTypes {
+		accepted = accepted || photoType == contentType
+	}
+	if !accepted {
+		return nil, &ValidationError{Field: "content_type", Reason: fmt.Sprintf("must be one of %s", strings.Join(photoTypes, ", "))}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(photo, MaxPhotoSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, &ValidationError{Field: "photo", Reason: "must not be empty"}
+	}
+	if len(data) > MaxPhotoSize {
+		return nil, &ValidationError{Field: "photo", Reason: fmt.Sprintf("must be at most %d bytes", MaxPhotoSize)}
+	}
+	if detected := http.DetectContentType(data); detected != contentType {
+		return nil, &ValidationError{Field: "photo", Reason: fmt.Sprintf("content is %s, not %s", detected, contentType)}
+	}
+
+	return data, nil
+}
+
+// upload sends a profile photo as multipart/form-data and parses the JSON response into T
+func upload[T any](ctx context.Context, c *Client, path string, field string, photo io.Reader, contentType string) *HttpResponse[T] {
+	data, err := readPhoto(photo, contentType)
+	if err != nil {
+		return invalid[T](err)
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename="photo.%s"`, field, strings.TrimPrefix(contentType, "image/")))
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return invalid[T](err)
+	}
+	part.Write(data)
+	if err := writer.Close(); err != nil {
+		return invalid[T](err)
+	}
+
+	resp, attempts, err := c.do(ctx, http.MethodPut, endpoint(path, nil), map[string]string{"Content-Type": writer.FormDataContentType()}, body.Bytes(), true)
+
+	result := parseResponse[T](resp, err)
+	result.Attempts = attempts
+
+	return result
+}
+
+// UploadPetPhoto replaces the profile photo of a pet with a JPEG or PNG image,
+// returning the URLs of the new photo by size (see Pet.ProfilePhotoUrlSizes).
+//
+// The endpoint and its profile_photo form field are not part of the documented collection
+// and are assumed.
+func (c *Client) UploadPetPhoto(petId string, photo io.Reader, contentType string) *HttpResponse[map[string]string] {
+	return c.UploadPetPhotoCtx(context.Background(), petId, photo, contentType)
+}
+
+// UploadPetPhotoCtx is the context-aware variant of UploadPetPhoto
+func (c *Client) UploadPetPhotoCtx(ctx context.Context, petId string, photo io.Reader, contentType string) *HttpResponse[map[string]string] {
+	resp := upload[PetResponse](ctx, c, fmt.Sprintf("api/pets/%s/profile_photo", url.PathEscape(petId)), "profile_photo", photo, contentType)
+
+	result := relay[map[string]string](resp)
+	result.Response = resp.Response.Pet.ProfilePhotoUrlSizes
+
+	return result
+}
+
+// UploadUserPhoto replaces the profile photo of the current user with a JPEG or PNG image,
+// returning the URLs of the new photo by size (see UsersResponse.ProfilePhotoSizes).
+//
+// The endpoint and its profile_photo form field are not part of the documented collection
+// and are assumed.
+func (c *Client) UploadUserPhoto(photo io.Reader, contentType string) *HttpResponse[map[string]string] {
+	return c.UploadUserPhotoCtx(context.Background(), photo, contentType)
+}
+
+// UploadUserPhotoCtx is the context-aware variant of UploadUserPhoto
+func (c *Client) UploadUserPhotoCtx(ctx context.Context, photo io.Reader, contentType string) *HttpResponse[map[string]string] {
+	resp := upload[MeResponse](ctx, c, "api/users/me/profile_photo", "profile_photo", photo, contentType)
+
+	result := relay[map[string]string](resp)
+	result.Response = resp.Response.User.ProfilePhotoSizes
+
+	return result
+}
+
+// DownloadPhoto downloads a profile photo by size (e.g. "small") from the URLs of
+// Pet.ProfilePhotoUrlSizes or UsersResponse.ProfilePhotoSizes.
+//
+// The photo is hosted outside of the API, so the request is sent without credentials
+// and does not go through the Middleware of the client. HTTPClient and Transport are used.
+func (c *Client) DownloadPhoto(sizes map[string]string, size string) *HttpResponse[[]byte] {
+	return c.DownloadPhotoCtx(context.Background(), sizes, size)
+}
+
+// DownloadPhotoCtx is the context-aware variant of DownloadPhoto
+func (c *Client) DownloadPhotoCtx(ctx context.Context, sizes map[string]string, size string) *HttpResponse[[]byte] {
+	photoUrl, ok := sizes[size]
+	if !ok || photoUrl == "" {
+		return invalid[[]byte](&ValidationError{Field: "size", Reason: fmt.Sprintf("no photo of size %q", size)})
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, photoUrl, nil)
+	if err != nil {
+		return invalid[[]byte](err)
+	}
+	request.Header.Set("User-Agent", c.UserAgent)
+
+	resp, err := c.plainHTTPClient().Do(request)
+	if err != nil {
+		return invalid[[]byte](canceled(ctx, err))
+	}
+	defer resp.Body.Close()
+
+	result := &HttpResponse[[]byte]{
+		StatusCode: resp.StatusCode,
+		Raw:        resp,
+		Attempts:   1,
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	switch {
+	case err != nil:
+		result.Error = canceled(ctx, err)
+	case !isSuccess(resp.StatusCode, nil):
+		result.Error = newAPIError(resp, body)
+	default:
+		result.Response = body
+	}
+
+	return result
+}
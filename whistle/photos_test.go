@@ -0,0 +1,136 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle_test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/amattu2/go-whistle-wrapper/whistle"
+	"github.com/go-playground/assert/v2"
+)
+
+// pngPhoto returns a small PNG image
+func pngPhoto() []byte {
+	photo := &bytes.Buffer{}
+	png.Encode(photo, image.NewRGBA(image.Rect(0, 0, 4, 4)))
+
+	return photo.Bytes()
+}
+
+func TestUploadPetPhoto(t *testing.T) {
+	t.Parallel()
+
+	photo := pngPhoto()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/pets/123/profile_photo", r.URL.Path)
+
+		file, header, err := r.FormFile("profile_photo")
+		assert.Equal(t, nil, err)
+		defer file.Close()
+
+		received := &bytes.Buffer{}
+		received.ReadFrom(file)
+		assert.Equal(t, "image/png", header.Header.Get("Content-Type"))
+		assert.Equal(t, photo, received.Bytes())
+
+		w.Write([]byte(`{"pet":{"id":123,"profile_photo_url_sizes":{"small":"https://cdn/small.png"}}}`))
+	}))
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.UploadPetPhoto("123", bytes.NewReader(photo), "image/png")
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, "https://cdn/small.png", resp.Response["small"])
+}
+
+func TestUploadPhotoInvalid(t *testing.T) {
+	t.Parallel()
+
+	client := whistle.InitializeBearer("abc123")
+	tests := map[string]func() *whistle.HttpResponse[map[string]string]{
+		"content_type": func() *whistle.HttpResponse[map[string]string] {
+			return client.UploadUserPhoto(bytes.NewReader(pngPhoto()), "image/bmp")
+		},
+		"photo": func() *whistle.HttpResponse[map[string]string] {
+			return client.UploadUserPhoto(strings.NewReader("not an image"), "image/jpeg")
+		},
+	}
+
+	for field, upload := range tests {
+		var validationErr *whistle.ValidationError
+		resp := upload()
+
+		assert.Equal(t, true, errors.As(resp.Error, &validationErr))
+		assert.Equal(t, field, validationErr.Field)
+	}
+
+	var validationErr *whistle.ValidationError
+	large := append(pngPhoto(), make([]byte, whistle.MaxPhotoSize)...)
+	assert.Equal(t, true, errors.As(client.UploadUserPhoto(bytes.NewReader(large), "image/png").Error, &validationErr))
+}
+
+func TestDownloadPhoto(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "", r.Header.Get("Authorization"))
+		assert.Equal(t, "", r.Header.Get("X-Trace"))
+		w.Write([]byte("photo-bytes"))
+	}))
+	defer server.Close()
+
+	var transported int32
+	client := whistle.InitializeBearer("abc123")
+	client.Transport = whistle.RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&transported, 1)
+
+		return http.DefaultTransport.RoundTrip(request)
+	})
+	client.Middleware = []whistle.Middleware{func(next http.RoundTripper) http.RoundTripper {
+		return whistle.RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			request = request.Clone(request.Context())
+			request.Header.Set("X-Trace", "api")
+
+			return next.RoundTrip(request)
+		})
+	}}
+	sizes := map[string]string{"small": server.URL + "/small.png"}
+
+	resp := client.DownloadPhoto(sizes, "small")
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, []byte("photo-bytes"), resp.Response)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&transported))
+
+	var validationErr *whistle.ValidationError
+	assert.Equal(t, true, errors.As(client.DownloadPhoto(sizes, "large").Error, &validationErr))
+}
@@ -0,0 +1,188 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	PlaceShapeCircle  = "circle"
+	PlaceShapePolygon = "polygon"
+
+	// Bounds of the radius of a circular place
+	MinPlaceRadiusMeters = 30.0
+	MaxPlaceRadiusMeters = 3000.0
+)
+
+// PlaceInput describes a place (safe zone) to be created or updated.
+//
+// A place is either circular, with RadiusMeters around Latitude/Longitude,
+// or a closed polygon described by Outline (the first and last points must match).
+type PlaceInput struct {
+	Name         string
+	Address      string
+	Latitude     float64
+	Longitude    float64
+	RadiusMeters float64
+	Outline      []LatLon
+	PetIds       []int
+	WifiNetwork  *WifiNetwork // Optional, see DeviceWifiNetworks()
+}
+
+type placeRequest struct {
+	Name         string       `json:"name"`
+	Address      string       `json:"address,omitempty"`
+	Latitude     string       `json:"latitude"`
+	Longitude    string       `json:"longitude"`
+	Shape        string       `json:"shape"`
+	RadiusMeters float64      `json:"radius_meters,omitempty"`
+	Outline      []placePoint `json:"outline,omitempty"`
+	PetIds       []int        `json:"pet_ids"`
+	WifiNetwork  *WifiNetwork `json:"wifi_network,omitempty"`
+}
+
+// placePoint is a point of a place outline, with the coordinates sent as strings like the center
+type placePoint struct {
+	Latitude  string `json:"latitude"`
+	Longitude string `json:"longitude"`
+}
+
+// request converts the input into the format expected by the API
+func (p PlaceInput) request() placeRequest {
+	request := placeRequest{
+		Name:         p.Name,
+		Address:      p.Address,
+		Latitude:     strconv.FormatFloat(p.Latitude, 'f', -1, 64),
+		Longitude:    strconv.FormatFloat(p.Longitude, 'f', -1, 64),
+		Shape:        PlaceShapeCircle,
+		RadiusMeters: p.RadiusMeters,
+		PetIds:       p.PetIds,
+		WifiNetwork:  p.WifiNetwork,
+	}
+	for _, point := range p.Outline {
+		request.Outline = append(request.Outline, placePoint{
+			Latitude:  strconv.FormatFloat(point.Latitude, 'f', -1, 64),
+			Longitude: strconv.FormatFloat(point.Longitude, 'f', -1, 64),
+		})
+	}
+	if len(p.Outline) > 0 {
+		request.Shape = PlaceShapePolygon
+	}
+	if request.PetIds == nil {
+		request.PetIds = []int{}
+	}
+
+	return request
+}
+
+// validate checks the name, coordinates and shape of the place
+func (p PlaceInput) validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return &ValidationError{Field: "name", Reason: "must not be empty"}
+	}
+	if err := validateLatLon("location", LatLon{p.Latitude, p.Longitude}); err != nil {
+		return err
+	}
+
+	switch {
+	case len(p.Outline) > 0 && p.RadiusMeters != 0:
+		return &ValidationError{Field: "shape", Reason: "must be either circular or a polygon, not both"}
+	case len(p.Outline) > 0:
+		if p.Outline[0] != p.Outline[len(p.Outline)-1] {
+			return &ValidationError{Field: "outline", Reason: "must be closed (first and last points must match)"}
+		}
+
+		// The closing point repeats the first one
+		distinct := map[LatLon]bool{}
+		for _, point := range p.Outline[:len(p.Outline)-1] {
+			if err := validateLatLon("outline", point); err != nil {
+				return err
+			}
+			distinct[point] = true
+		}
+		if len(distinct) < 3 {
+			return &ValidationError{Field: "outline", Reason: "must have at least 3 distinct points"}
+		}
+	case p.RadiusMeters < MinPlaceRadiusMeters || p.RadiusMeters > MaxPlaceRadiusMeters:
+		return &ValidationError{Field: "radius_meters", Reason: fmt.Sprintf("must be between %g and %g", MinPlaceRadiusMeters, MaxPlaceRadiusMeters)}
+	}
+
+	return nil
+}
+
+// validateLatLon checks that a point is a valid coordinate
+func validateLatLon(field string, point LatLon) error {
+	if point.Latitude < -90 || point.Latitude > 90 || point.Longitude < -180 || point.Longitude > 180 {
+		return &ValidationError{Field: field, Reason: fmt.Sprintf("invalid coordinate %g,%g", point.Latitude, point.Longitude)}
+	}
+
+	return nil
+}
+
+// CreatePlace creates a new place (safe zone) for the current user
+func (c *Client) CreatePlace(place PlaceInput) *HttpResponse[Place] {
+	return c.CreatePlaceCtx(context.Background(), place)
+}
+
+// CreatePlaceCtx is the context-aware variant of CreatePlace
+func (c *Client) CreatePlaceCtx(ctx context.Context, place PlaceInput) *HttpResponse[Place] {
+	if err := place.validate(); err != nil {
+		return invalid[Place](err)
+	}
+
+	return Do[Place](ctx, c, http.MethodPost, "api/places", nil, place.request())
+}
+
+// UpdatePlace replaces the name, shape, pets and Wifi network of a place.
+//
+// The endpoint is not part of the documented collection, which only covers listing and
+// creating places, and is assumed to accept the body of CreatePlace.
+func (c *Client) UpdatePlace(placeId string, place PlaceInput) *HttpResponse[Place] {
+	return c.UpdatePlaceCtx(context.Background(), placeId, place)
+}
+
+// UpdatePlaceCtx is the context-aware variant of UpdatePlace
+func (c *Client) UpdatePlaceCtx(ctx context.Context, placeId string, place PlaceInput) *HttpResponse[Place] {
+	if err := place.validate(); err != nil {
+		return invalid[Place](err)
+	}
+
+	return Do[Place](ctx, c, http.MethodPut, "api/places/"+url.PathEscape(placeId), nil, place.request())
+}
+
+// DeletePlace deletes a place.
+//
+// The endpoint is not part of the documented collection and is assumed.
+func (c *Client) DeletePlace(placeId string) *HttpResponse[bool] {
+	return c.DeletePlaceCtx(context.Background(), placeId)
+}
+
+// DeletePlaceCtx is the context-aware variant of DeletePlace
+func (c *Client) DeletePlaceCtx(ctx context.Context, placeId string) *HttpResponse[bool] {
+	return confirm(ctx, c, http.MethodDelete, "api/places/"+url.PathEscape(placeId), nil)
+}
@@ -0,0 +1,147 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/amattu2/go-whistle-wrapper/whistle"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestCreatePlaceCircle(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusCreated, `{"id":10,"name":"Kennel","shape":"circle","radius_meters":100}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.CreatePlace(whistle.PlaceInput{
+		Name:         "Kennel",
+		Latitude:     38.8977,
+		Longitude:    -77.0365,
+		RadiusMeters: 100,
+		PetIds:       []int{1},
+	})
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, 10, resp.Response.ID)
+	assert.Equal(t, http.MethodPost, last().Method)
+	assert.Equal(t, "/api/places", last().Path)
+	assert.Equal(t, map[string]any{
+		"name":          "Kennel",
+		"latitude":      "38.8977",
+		"longitude":     "-77.0365",
+		"shape":         "circle",
+		"radius_meters": 100.0,
+		"pet_ids":       []any{1.0},
+	}, last().Body)
+}
+
+func TestUpdatePlacePolygon(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusOK, `{"id":10,"shape":"polygon"}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.UpdatePlace("10", whistle.PlaceInput{
+		Name:      "Yard",
+		Latitude:  1,
+		Longitude: 1,
+		Outline:   []whistle.LatLon{{1, 1}, {1, 2}, {2, 2}, {1, 1}},
+		WifiNetwork: &whistle.WifiNetwork{
+			ID:   5,
+			SSID: "home",
+		},
+	})
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, whistle.PlaceShapePolygon, resp.Response.Shape)
+	assert.Equal(t, http.MethodPut, last().Method)
+	assert.Equal(t, "/api/places/10", last().Path)
+	assert.Equal(t, "polygon", last().Body["shape"])
+	assert.Equal(t, 4, len(last().Body["outline"].([]any)))
+	assert.Equal(t, map[string]any{"latitude": "1", "longitude": "2"}, last().Body["outline"].([]any)[1])
+	assert.Equal(t, "home", last().Body["wifi_network"].(map[string]any)["ssid"])
+}
+
+func TestPlaceInvalid(t *testing.T) {
+	t.Parallel()
+
+	client := whistle.InitializeBearer("abc123")
+	tests := map[string]whistle.PlaceInput{
+		"name":          {RadiusMeters: 100},
+		"location":      {Name: "Yard", Latitude: 91, RadiusMeters: 100},
+		"radius_meters": {Name: "Yard", RadiusMeters: 5},
+		"shape":         {Name: "Yard", RadiusMeters: 100, Outline: []whistle.LatLon{{1, 1}, {1, 2}, {2, 2}, {1, 1}}},
+		"outline":       {Name: "Yard", Outline: []whistle.LatLon{{1, 1}, {1, 2}, {2, 2}, {2, 1}}},
+	}
+
+	for field, place := range tests {
+		var validationErr *whistle.ValidationError
+		resp := client.CreatePlace(place)
+
+		assert.Equal(t, true, errors.As(resp.Error, &validationErr))
+		assert.Equal(t, field, validationErr.Field)
+	}
+}
+
+func TestPlaceOutlineDistinctPoints(t *testing.T) {
+	t.Parallel()
+
+	client := whistle.InitializeBearer("abc123")
+	outlines := [][]whistle.LatLon{
+		{{1, 1}},
+		{{1, 1}, {1, 1}, {1, 1}, {1, 1}},
+		{{1, 1}, {1, 2}, {1, 1}, {1, 2}, {1, 1}},
+	}
+
+	for _, outline := range outlines {
+		var validationErr *whistle.ValidationError
+		resp := client.CreatePlace(whistle.PlaceInput{Name: "Yard", Outline: outline})
+
+		assert.Equal(t, true, errors.As(resp.Error, &validationErr))
+		assert.Equal(t, "outline", validationErr.Field)
+		assert.Equal(t, "must have at least 3 distinct points", validationErr.Reason)
+	}
+}
+
+func TestDeletePlace(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusNoContent, "")
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.DeletePlace("10")
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, true, resp.Response)
+	assert.Equal(t, http.MethodDelete, last().Method)
+	assert.Equal(t, "/api/places/10", last().Path)
+}
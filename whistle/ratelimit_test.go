@@ -0,0 +1,101 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/amattu2/go-whistle-wrapper/whistle"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	t.Parallel()
+
+	limiter := whistle.NewRateLimiter(20, 2)
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		assert.Equal(t, nil, limiter.Wait(context.Background()))
+	}
+
+	// 2 requests are free, the remaining 4 are spaced 50ms apart
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("Expected rate limiter to wait at least 150ms, waited %s", elapsed)
+	}
+}
+
+func TestRateLimiterCanceled(t *testing.T) {
+	t.Parallel()
+
+	limiter := whistle.NewRateLimiter(0.01, 1)
+	assert.Equal(t, nil, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := limiter.Wait(ctx)
+
+	var canceledErr *whistle.CanceledError
+	assert.Equal(t, true, errors.As(err, &canceledErr))
+}
+
+func TestRateLimiterThrottle(t *testing.T) {
+	t.Parallel()
+
+	limiter := whistle.NewRateLimiter(100, 10)
+	limiter.Throttle(100 * time.Millisecond)
+
+	start := time.Now()
+	assert.Equal(t, nil, limiter.Wait(context.Background()))
+
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Expected throttled rate limiter to wait, waited %s", elapsed)
+	}
+}
+
+func TestRateLimiterTooManyRequests(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	client.RateLimiter = whistle.NewRateLimiter(100, 10)
+
+	assert.Equal(t, http.StatusTooManyRequests, client.Breeds("dogs").StatusCode)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	resp := client.BreedsCtx(ctx, "dogs")
+
+	assert.Equal(t, true, errors.Is(resp.Error, context.DeadlineExceeded))
+}
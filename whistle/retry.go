@@ -0,0 +1,195 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how failed requests are retried
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// A value of 1 or less disables retries.
+	MaxAttempts int
+
+	// InitialBackoff is the wait before the first retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the wait between attempts. A Retry-After header asking
+	// for a longer wait stops the retries and the response is returned as-is.
+	MaxBackoff time.Duration
+
+	// Multiplier is applied to the backoff after each attempt
+	Multiplier float64
+
+	// Jitter randomizes each backoff by up to this fraction (0.0 - 1.0)
+	Jitter float64
+
+	// Methods lists the HTTP methods that may be retried (default: GET, HEAD)
+	Methods []string
+
+	// RetryableStatusCodes lists the HTTP status codes that are retried
+	RetryableStatusCodes []int
+
+	// RetryableError decides whether a transport error is retried.
+	// When nil, every transport error is retried. Login, validation and
+	// canceled requests are never retried.
+	RetryableError func(err error) bool
+}
+
+// retryPolicyKey is the context key of a per-call RetryPolicy
+type retryPolicyKey struct{}
+
+// DefaultRetryPolicy returns a policy retrying idempotent requests up to 3 times
+// on network errors, HTTP 429, 502, 503 and 504
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		Methods:        []string{http.MethodGet, http.MethodHead},
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// NoRetry returns a policy that disables retries
+func NoRetry() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 1}
+}
+
+// WithRetryPolicy returns a context that overrides the client RetryPolicy for calls made with it
+func WithRetryPolicy(ctx context.Context, policy *RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+// retryPolicy returns the policy of the call, falling back to the client policy
+func (c *Client) retryPolicy(ctx context.Context) *RetryPolicy {
+	if policy, ok := ctx.Value(retryPolicyKey{}).(*RetryPolicy); ok {
+		return policy
+	}
+
+	return c.Retry
+}
+
+// allowsMethod checks whether requests with the HTTP method may be retried
+func (p *RetryPolicy) allowsMethod(method string) bool {
+	methods := p.Methods
+	if methods == nil {
+		methods = []string{http.MethodGet, http.MethodHead}
+	}
+
+	for _, m := range methods {
+		if m == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// shouldRetry checks whether the outcome of an attempt is retryable
+func (p *RetryPolicy) shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		var canceledErr *CanceledError
+		var transportErr *url.Error
+		if errors.As(err, &canceledErr) || !errors.As(err, &transportErr) {
+			return false
+		}
+		if p.RetryableError != nil {
+			return p.RetryableError(err)
+		}
+
+		return true
+	}
+
+	return hasStatus(resp.StatusCode, p.RetryableStatusCodes)
+}
+
+// backoff returns the wait before the given retry (1 = first retry)
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	wait := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.Jitter > 0 {
+		wait += wait * p.Jitter * (2*rand.Float64() - 1)
+	}
+	if p.MaxBackoff > 0 && wait > float64(p.MaxBackoff) {
+		wait = float64(p.MaxBackoff)
+	}
+
+	return time.Duration(wait)
+}
+
+// retryAfter parses the Retry-After header of a response (seconds or HTTP date)
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// sleep waits for d or until the context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return &CanceledError{Err: ctx.Err()}
+	}
+}
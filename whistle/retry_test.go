@@ -0,0 +1,229 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/amattu2/go-whistle-wrapper/whistle"
+	"github.com/go-playground/assert/v2"
+)
+
+// fastRetry returns the default policy with short waits for testing
+func fastRetry() *whistle.RetryPolicy {
+	policy := whistle.DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 10 * time.Millisecond
+
+	return policy
+}
+
+// flakyServer fails the first n requests with the given status
+func flakyServer(n int32, status int, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(calls, 1) <= n {
+			w.WriteHeader(status)
+			return
+		}
+
+		w.Write([]byte(`{"breeds":[{"id":1,"name":"Beagle"}]}`))
+	}))
+}
+
+func TestRetryTransientStatus(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := flakyServer(2, http.StatusBadGateway, &calls)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	client.Retry = fastRetry()
+
+	resp := client.Breeds("dogs")
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, resp.Attempts)
+	assert.Equal(t, "Beagle", resp.Response.Breeds[0].Name)
+}
+
+func TestRetryExhausted(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := flakyServer(10, http.StatusServiceUnavailable, &calls)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	client.Retry = fastRetry()
+
+	resp := client.Breeds("dogs")
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 3, resp.Attempts)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRetryDisabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := flakyServer(1, http.StatusBadGateway, &calls)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+
+	resp := client.Breeds("dogs")
+
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, 1, resp.Attempts)
+}
+
+func TestRetryPerCallOverride(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := flakyServer(1, http.StatusBadGateway, &calls)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	client.Retry = fastRetry()
+
+	resp := client.BreedsCtx(whistle.WithRetryPolicy(context.Background(), whistle.NoRetry()), "dogs")
+
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, 1, resp.Attempts)
+}
+
+func TestRetryAfterTooLong(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	client.Retry = fastRetry()
+
+	resp := client.Breeds("dogs")
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, 1, resp.Attempts)
+}
+
+func TestRetryNotAppliedToLogin(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := flakyServer(10, http.StatusBadGateway, &calls)
+	defer server.Close()
+
+	client := whistle.Initialize("abc@gmail.com", "xyz")
+	client.Env = server.URL
+	client.Retry = fastRetry()
+
+	assert.Equal(t, true, errors.Is(client.Login(), whistle.ErrAuthUnavailable))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetryNotAppliedToInvalidCredentials(t *testing.T) {
+	t.Parallel()
+
+	var logins int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&logins, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := whistle.Initialize("abc@gmail.com", "wrong")
+	client.Env = server.URL
+	client.Retry = fastRetry()
+	client.Retry.MaxAttempts = 5
+
+	resp := client.Pets()
+
+	assert.Equal(t, true, errors.Is(resp.Error, whistle.ErrInvalidCredentials))
+	assert.Equal(t, 1, resp.Attempts)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&logins))
+}
+
+func TestRetryNetworkError(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+
+		w.Write([]byte(`{"breeds":[]}`))
+	}))
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	client.Retry = fastRetry()
+
+	resp := client.Breeds("dogs")
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, 2, resp.Attempts)
+}
+
+func TestRetryCanceledDuringBackoff(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := flakyServer(10, http.StatusBadGateway, &calls)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	client.Retry = whistle.DefaultRetryPolicy()
+	client.Retry.InitialBackoff = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	resp := client.BreedsCtx(ctx, "dogs")
+
+	assert.Equal(t, true, errors.Is(resp.Error, context.DeadlineExceeded))
+	assert.Equal(t, 1, resp.Attempts)
+}
@@ -0,0 +1,126 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type PetInvitationResponse struct {
+	Errors     []Error       `json:"errors"`
+	Invitation PetInvitation `json:"invitation"`
+}
+
+type PetInvitation struct {
+	Code      string `json:"code"`
+	Email     string `json:"email"`
+	PetId     int    `json:"pet_id"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+type TransferResponse struct {
+	Errors   []Error     `json:"errors"`
+	Transfer TransferPet `json:"transfer"`
+}
+
+// CreatePetInvitation generates an invitation code to share a pet with another user.
+// When an email address is provided, the invitation is also sent to it.
+//
+// The endpoint is not part of the documented collection, which only covers looking up
+// invitation codes (see InvitationCodes), and is assumed.
+func (c *Client) CreatePetInvitation(petId string, email string) *HttpResponse[PetInvitationResponse] {
+	return c.CreatePetInvitationCtx(context.Background(), petId, email)
+}
+
+// CreatePetInvitationCtx is the context-aware variant of CreatePetInvitation
+func (c *Client) CreatePetInvitationCtx(ctx context.Context, petId string, email string) *HttpResponse[PetInvitationResponse] {
+	invitation := map[string]string{}
+	if email != "" {
+		if !strings.Contains(email, "@") {
+			return invalid[PetInvitationResponse](&ValidationError{Field: "email", Reason: "must be an email address"})
+		}
+		invitation["email"] = email
+	}
+
+	return Do[PetInvitationResponse](ctx, c, http.MethodPost, fmt.Sprintf("api/pets/%s/invitation_codes", url.PathEscape(petId)), nil, map[string]any{
+		"invitation": invitation,
+	})
+}
+
+// RedeemInvitationCode adds the pet of an invitation code (see InvitationCodes) to the current user.
+//
+// The endpoint is not part of the documented collection and is assumed.
+func (c *Client) RedeemInvitationCode(code string) *HttpResponse[PetResponse] {
+	return c.RedeemInvitationCodeCtx(context.Background(), code)
+}
+
+// RedeemInvitationCodeCtx is the context-aware variant of RedeemInvitationCode
+func (c *Client) RedeemInvitationCodeCtx(ctx context.Context, code string) *HttpResponse[PetResponse] {
+	if strings.TrimSpace(code) == "" {
+		return invalid[PetResponse](&ValidationError{Field: "code", Reason: "must not be empty"})
+	}
+
+	return Do[PetResponse](ctx, c, http.MethodPost, fmt.Sprintf("api/users/invitation_codes/%s/redeem", url.PathEscape(code)), nil, nil)
+}
+
+// AcceptPetTransfer accepts a pending pet transfer (see PetTransfers).
+//
+// The endpoint is not part of the documented collection, which only lists transfers,
+// and is assumed.
+func (c *Client) AcceptPetTransfer(transferId int) *HttpResponse[TransferResponse] {
+	return c.AcceptPetTransferCtx(context.Background(), transferId)
+}
+
+// AcceptPetTransferCtx is the context-aware variant of AcceptPetTransfer
+func (c *Client) AcceptPetTransferCtx(ctx context.Context, transferId int) *HttpResponse[TransferResponse] {
+	return Do[TransferResponse](ctx, c, http.MethodPut, fmt.Sprintf("api/pets/transfers/%d/accept", transferId), nil, nil)
+}
+
+// DeclinePetTransfer declines a pending pet transfer (see PetTransfers).
+//
+// The endpoint is not part of the documented collection, which only lists transfers,
+// and is assumed.
+func (c *Client) DeclinePetTransfer(transferId int) *HttpResponse[TransferResponse] {
+	return c.DeclinePetTransferCtx(context.Background(), transferId)
+}
+
+// DeclinePetTransferCtx is the context-aware variant of DeclinePetTransfer
+func (c *Client) DeclinePetTransferCtx(ctx context.Context, transferId int) *HttpResponse[TransferResponse] {
+	return Do[TransferResponse](ctx, c, http.MethodPut, fmt.Sprintf("api/pets/transfers/%d/decline", transferId), nil, nil)
+}
+
+// RemovePetOwner removes an owner (see PetOwners) from a pet.
+//
+// The endpoint is not part of the documented collection, which only lists owners,
+// and is assumed.
+func (c *Client) RemovePetOwner(petId string, ownerId int) *HttpResponse[bool] {
+	return c.RemovePetOwnerCtx(context.Background(), petId, ownerId)
+}
+
+// RemovePetOwnerCtx is the context-aware variant of RemovePetOwner
+func (c *Client) RemovePetOwnerCtx(ctx context.Context, petId string, ownerId int) *HttpResponse[bool] {
+	return confirm(ctx, c, http.MethodDelete, fmt.Sprintf("api/pets/%s/owners/%d", url.PathEscape(petId), ownerId), nil)
+}
@@ -0,0 +1,99 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/amattu2/go-whistle-wrapper/whistle"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestCreatePetInvitation(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusCreated, `{"invitation":{"code":"XYZ789","pet_id":123}}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.CreatePetInvitation("123", "adopter@gmail.com")
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, "XYZ789", resp.Response.Invitation.Code)
+	assert.Equal(t, http.MethodPost, last().Method)
+	assert.Equal(t, "/api/pets/123/invitation_codes", last().Path)
+	assert.Equal(t, map[string]any{"invitation": map[string]any{"email": "adopter@gmail.com"}}, last().Body)
+
+	var validationErr *whistle.ValidationError
+	assert.Equal(t, true, errors.As(client.CreatePetInvitation("123", "adopter").Error, &validationErr))
+}
+
+func TestRedeemInvitationCode(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusOK, `{"pet":{"id":123,"name":"Barker"}}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.RedeemInvitationCode("XYZ789")
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, "Barker", resp.Response.Pet.Name)
+	assert.Equal(t, "/api/users/invitation_codes/XYZ789/redeem", last().Path)
+}
+
+func TestPetTransferDecision(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusOK, `{"transfer":{"id":8,"status":"accepted"}}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+
+	resp := client.AcceptPetTransfer(8)
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, 8, resp.Response.Transfer.ID)
+	assert.Equal(t, http.MethodPut, last().Method)
+	assert.Equal(t, "/api/pets/transfers/8/accept", last().Path)
+
+	client.DeclinePetTransfer(9)
+	assert.Equal(t, "/api/pets/transfers/9/decline", last().Path)
+}
+
+func TestRemovePetOwner(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusNoContent, "")
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+
+	assert.Equal(t, true, client.RemovePetOwner("123", 42).Response)
+	assert.Equal(t, http.MethodDelete, last().Method)
+	assert.Equal(t, "/api/pets/123/owners/42", last().Path)
+}
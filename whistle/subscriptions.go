@@ -0,0 +1,138 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type CouponResponse struct {
+	Errors []Error `json:"errors"`
+	Coupon Coupon  `json:"coupon"`
+}
+
+type SubscriptionResponse struct {
+	Errors       []Error      `json:"errors"`
+	Subscription Subscription `json:"subscription"`
+}
+
+// Coupon returns a coupon and whether it applies to a subscription plan (see DevicePlans)
+func (c *Client) Coupon(couponId string, planId string) *HttpResponse[CouponResponse] {
+	return c.CouponCtx(context.Background(), couponId, planId)
+}
+
+// CouponCtx is the context-aware variant of Coupon
+func (c *Client) CouponCtx(ctx context.Context, couponId string, planId string) *HttpResponse[CouponResponse] {
+	query := url.Values{}
+	query.Set("plan_id", planId)
+
+	return Do[CouponResponse](ctx, c, http.MethodGet, fmt.Sprintf("api/coupons/%s", url.PathEscape(couponId)), query, nil)
+}
+
+// PreviewPlanChange returns the prorated amounts of changing the plan of a device subscription,
+// with an optional coupon
+func (c *Client) PreviewPlanChange(deviceId string, planId string, couponId string) *HttpResponse[DeviceSubscriptionPreviewResponse] {
+	return c.PreviewPlanChangeCtx(context.Background(), deviceId, planId, couponId)
+}
+
+// PreviewPlanChangeCtx is the context-aware variant of PreviewPlanChange
+func (c *Client) PreviewPlanChangeCtx(ctx context.Context, deviceId string, planId string, couponId string) *HttpResponse[DeviceSubscriptionPreviewResponse] {
+	if couponId == "" {
+		return c.DeviceSubscriptionPreviewCtx(ctx, deviceId, planId)
+	}
+
+	query := url.Values{}
+	query.Set("coupon_id", couponId)
+
+	return Do[DeviceSubscriptionPreviewResponse](ctx, c, http.MethodGet, fmt.Sprintf("api/devices/%s/subscription/previews/%s", url.PathEscape(deviceId), url.PathEscape(planId)), query, nil)
+}
+
+// ChangePlan changes the plan of a device subscription, with an optional coupon.
+//
+// Updating the subscription is not part of the documented collection, which only reads it,
+// and is assumed.
+func (c *Client) ChangePlan(deviceId string, planId string, couponId string) *HttpResponse[DeviceSubscriptionResponse] {
+	return c.ChangePlanCtx(context.Background(), deviceId, planId, couponId)
+}
+
+// ChangePlanCtx is the context-aware variant of ChangePlan
+func (c *Client) ChangePlanCtx(ctx context.Context, deviceId string, planId string, couponId string) *HttpResponse[DeviceSubscriptionResponse] {
+	if strings.TrimSpace(planId) == "" {
+		return invalid[DeviceSubscriptionResponse](&ValidationError{Field: "plan_id", Reason: "is required"})
+	}
+
+	subscription := map[string]string{"plan_id": planId}
+	if couponId != "" {
+		subscription["coupon_id"] = couponId
+	}
+
+	return Do[DeviceSubscriptionResponse](ctx, c, http.MethodPut, fmt.Sprintf("api/devices/%s/subscription", url.PathEscape(deviceId)), nil, map[string]any{
+		"subscription": subscription,
+	})
+}
+
+// CancelSubscription cancels a subscription with a reason from CancellationReasons and an optional comment.
+//
+// The endpoint is not part of the documented collection, which only covers the cancellation
+// preview and reasons, and is assumed.
+func (c *Client) CancelSubscription(subId string, reasonId int, comment string) *HttpResponse[SubscriptionResponse] {
+	return c.CancelSubscriptionCtx(context.Background(), subId, reasonId, comment)
+}
+
+// CancelSubscriptionCtx is the context-aware variant of CancelSubscription
+func (c *Client) CancelSubscriptionCtx(ctx context.Context, subId string, reasonId int, comment string) *HttpResponse[SubscriptionResponse] {
+	reasons := c.CancellationReasonsCtx(ctx, subId)
+	if reasons.Error != nil {
+		return relay[SubscriptionResponse](reasons)
+	}
+
+	known := false
+	for _, reason := range reasons.Response.CancellationReasons {
+		known = known || reason.ID == reasonId
+	}
+	if !known {
+		return invalid[SubscriptionResponse](&ValidationError{Field: "reason_id", Reason: fmt.Sprintf("%d is not a cancellation reason of the subscription", reasonId)})
+	}
+
+	return Do[SubscriptionResponse](ctx, c, http.MethodPost, fmt.Sprintf("api/subscriptions/%s/cancellation", url.PathEscape(subId)), nil, map[string]any{
+		"cancellation": map[string]any{
+			"reason_id": reasonId,
+			"comment":   comment,
+		},
+	})
+}
+
+// ReactivateSubscription reactivates a cancelled subscription before the cancellation takes effect.
+//
+// The endpoint is not part of the documented collection and is assumed.
+func (c *Client) ReactivateSubscription(subId string) *HttpResponse[SubscriptionResponse] {
+	return c.ReactivateSubscriptionCtx(context.Background(), subId)
+}
+
+// ReactivateSubscriptionCtx is the context-aware variant of ReactivateSubscription
+func (c *Client) ReactivateSubscriptionCtx(ctx context.Context, subId string) *HttpResponse[SubscriptionResponse] {
+	return Do[SubscriptionResponse](ctx, c, http.MethodPost, fmt.Sprintf("api/subscriptions/%s/reactivation", url.PathEscape(subId)), nil, nil)
+}
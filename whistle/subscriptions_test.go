@@ -0,0 +1,140 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amattu2/go-whistle-wrapper/whistle"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestCoupon(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusOK, `{"coupon":{"id":"SAVE10","valid":true,"percent_off":10}}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.Coupon("SAVE10", "plan1")
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, 10.0, resp.Response.Coupon.PercentOff)
+	assert.Equal(t, "/api/coupons/SAVE10", last().Path)
+	assert.Equal(t, "plan1", last().Query.Get("plan_id"))
+}
+
+func TestPreviewPlanChange(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusOK, `{"preview":{"plan_id":"plan2","prorated_amount":4.5,"discount":1,"total":3.5}}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.PreviewPlanChange("ABC123", "plan2", "SAVE10")
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, 4.5, resp.Response.Preview.ProratedAmount)
+	assert.Equal(t, "/api/devices/ABC123/subscription/previews/plan2", last().Path)
+	assert.Equal(t, "SAVE10", last().Query.Get("coupon_id"))
+}
+
+func TestChangePlan(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusOK, `{"subscription":{"id":"sub1","plan":{"id":"plan2"}}}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.ChangePlan("ABC123", "plan2", "")
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, "plan2", resp.Response.Subscription.Plan.ID)
+	assert.Equal(t, http.MethodPut, last().Method)
+	assert.Equal(t, "/api/devices/ABC123/subscription", last().Path)
+	assert.Equal(t, map[string]any{"subscription": map[string]any{"plan_id": "plan2"}}, last().Body)
+}
+
+func TestCancelSubscription(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/subscriptions/sub1/cancellation/reasons", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"cancellation_reasons":[{"id":1,"short_name":"price"},{"id":2,"short_name":"moving"}]}`))
+	})
+	mux.HandleFunc("/api/subscriptions/sub1/cancellation", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		w.Write([]byte(`{"subscription":{"id":"sub1","status":"canceled"}}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+
+	resp := client.CancelSubscription("sub1", 2, "Moving abroad")
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, "canceled", resp.Response.Subscription.Status)
+
+	var validationErr *whistle.ValidationError
+	resp = client.CancelSubscription("sub1", 3, "")
+	assert.Equal(t, true, errors.As(resp.Error, &validationErr))
+	assert.Equal(t, "reason_id", validationErr.Field)
+}
+
+func TestCancellationPreview(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusOK, `{"preview":{"effective_on":"2024-02-01","refund_amount":12.5,"currency":"USD"}}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.CancellationPreview("sub1")
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, 12.5, resp.Response.Preview.RefundAmount)
+	assert.Equal(t, http.MethodGet, last().Method)
+	assert.Equal(t, "/api/subscriptions/sub1/cancellation/preview", last().Path)
+}
+
+func TestReactivateSubscription(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusOK, `{"subscription":{"id":"sub1","status":"active"}}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.ReactivateSubscription("sub1")
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, "active", resp.Response.Subscription.Status)
+	assert.Equal(t, http.MethodPost, last().Method)
+	assert.Equal(t, "/api/subscriptions/sub1/reactivation", last().Path)
+}
@@ -0,0 +1,197 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	TaskTypeMedication = "medication"
+	TaskTypeGrooming   = "grooming"
+	TaskTypeVetVisit   = "vet_visit"
+	TaskTypeOther      = "other"
+
+	TaskFrequencyOnce    = "once"
+	TaskFrequencyDaily   = "daily"
+	TaskFrequencyWeekly  = "weekly"
+	TaskFrequencyMonthly = "monthly"
+
+	TaskOccurrenceComplete = "complete"
+	TaskOccurrenceSkipped  = "skipped"
+)
+
+// PetTaskInput describes a pet task (medication, grooming, vet visit...) to be created or updated
+type PetTaskInput struct {
+	Title        string            `json:"title"`
+	TaskType     string            `json:"task_type"` // TaskTypeMedication, TaskTypeGrooming, TaskTypeVetVisit or TaskTypeOther
+	Notes        string            `json:"notes,omitempty"`
+	StartDate    string            `json:"start_date"`               // YYYY-MM-DD
+	DueTime      string            `json:"due_time"`                 // HH:MM, 24-hour clock
+	TimeZoneName string            `json:"time_zone_name,omitempty"` // e.g. America/New_York
+	Recurrence   PetTaskRecurrence `json:"recurrence"`
+}
+
+// validate checks the title, type and schedule of the task
+func (t PetTaskInput) validate() error {
+	if strings.TrimSpace(t.Title) == "" {
+		return &ValidationError{Field: "title", Reason: "must not be empty"}
+	}
+
+	switch t.TaskType {
+	case TaskTypeMedication, TaskTypeGrooming, TaskTypeVetVisit, TaskTypeOther:
+	default:
+		return &ValidationError{Field: "task_type", Reason: fmt.Sprintf("unknown task type %q", t.TaskType)}
+	}
+
+	if _, err := time.Parse("2006-01-02", t.StartDate); err != nil {
+		return &ValidationError{Field: "start_date", Reason: "must be formatted as YYYY-MM-DD"}
+	}
+	if _, err := time.Parse("15:04", t.DueTime); err != nil {
+		return &ValidationError{Field: "due_time", Reason: "must be formatted as HH:MM"}
+	}
+
+	switch t.Recurrence.Frequency {
+	case TaskFrequencyOnce, TaskFrequencyDaily, TaskFrequencyWeekly, TaskFrequencyMonthly:
+	default:
+		return &ValidationError{Field: "recurrence.frequency", Reason: fmt.Sprintf("unknown frequency %q", t.Recurrence.Frequency)}
+	}
+	if t.Recurrence.Interval < 0 {
+		return &ValidationError{Field: "recurrence.interval", Reason: "must not be negative"}
+	}
+	if len(t.Recurrence.DaysOfWeek) > 0 && t.Recurrence.Frequency != TaskFrequencyWeekly {
+		return &ValidationError{Field: "recurrence.days_of_week", Reason: "only applies to weekly tasks"}
+	}
+	for _, day := range t.Recurrence.DaysOfWeek {
+		if !isWeekday(day) {
+			return &ValidationError{Field: "recurrence.days_of_week", Reason: fmt.Sprintf("unknown day %q", day)}
+		}
+	}
+
+	return nil
+}
+
+// isWeekday checks whether the value is the name of a day of the week (e.g. monday)
+func isWeekday(value string) bool {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PetTasks returns the tasks of a pet.
+//
+// Listing tasks is not part of the documented collection, which only covers a single task,
+// and is assumed.
+func (c *Client) PetTasks(petId string) *HttpResponse[PetTasksResponse] {
+	return c.PetTasksCtx(context.Background(), petId)
+}
+
+// PetTasksCtx is the context-aware variant of PetTasks
+func (c *Client) PetTasksCtx(ctx context.Context, petId string) *HttpResponse[PetTasksResponse] {
+	return Do[PetTasksResponse](ctx, c, http.MethodGet, fmt.Sprintf("api/pets/%s/tasks", url.PathEscape(petId)), nil, nil)
+}
+
+// CreatePetTask creates a task for a pet.
+//
+// The endpoint is not part of the documented collection and is assumed.
+func (c *Client) CreatePetTask(petId string, task PetTaskInput) *HttpResponse[PetTaskResponse] {
+	return c.CreatePetTaskCtx(context.Background(), petId, task)
+}
+
+// CreatePetTaskCtx is the context-aware variant of CreatePetTask
+func (c *Client) CreatePetTaskCtx(ctx context.Context, petId string, task PetTaskInput) *HttpResponse[PetTaskResponse] {
+	if err := task.validate(); err != nil {
+		return invalid[PetTaskResponse](err)
+	}
+
+	return Do[PetTaskResponse](ctx, c, http.MethodPost, fmt.Sprintf("api/pets/%s/tasks", url.PathEscape(petId)), nil, map[string]any{"task": task})
+}
+
+// UpdatePetTask replaces the title, notes and schedule of a pet task.
+//
+// The endpoint is not part of the documented collection and is assumed.
+func (c *Client) UpdatePetTask(petId string, taskId string, task PetTaskInput) *HttpResponse[PetTaskResponse] {
+	return c.UpdatePetTaskCtx(context.Background(), petId, taskId, task)
+}
+
+// UpdatePetTaskCtx is the context-aware variant of UpdatePetTask
+func (c *Client) UpdatePetTaskCtx(ctx context.Context, petId string, taskId string, task PetTaskInput) *HttpResponse[PetTaskResponse] {
+	if err := task.validate(); err != nil {
+		return invalid[PetTaskResponse](err)
+	}
+
+	return Do[PetTaskResponse](ctx, c, http.MethodPut, fmt.Sprintf("api/pets/%s/tasks/%s", url.PathEscape(petId), url.PathEscape(taskId)), nil, map[string]any{"task": task})
+}
+
+// DeletePetTask deletes a pet task and its future occurrences.
+//
+// The endpoint is not part of the documented collection and is assumed.
+func (c *Client) DeletePetTask(petId string, taskId string) *HttpResponse[bool] {
+	return c.DeletePetTaskCtx(context.Background(), petId, taskId)
+}
+
+// DeletePetTaskCtx is the context-aware variant of DeletePetTask
+func (c *Client) DeletePetTaskCtx(ctx context.Context, petId string, taskId string) *HttpResponse[bool] {
+	return confirm(ctx, c, http.MethodDelete, fmt.Sprintf("api/pets/%s/tasks/%s", url.PathEscape(petId), url.PathEscape(taskId)), nil)
+}
+
+// CompletePetTaskOccurrence marks an occurrence of a pet task as complete.
+//
+// Updating occurrences is not part of the documented collection, which only lists them,
+// and is assumed.
+func (c *Client) CompletePetTaskOccurrence(petId string, occurrenceId string) *HttpResponse[PetTaskOccurrenceUpdateResponse] {
+	return c.CompletePetTaskOccurrenceCtx(context.Background(), petId, occurrenceId)
+}
+
+// CompletePetTaskOccurrenceCtx is the context-aware variant of CompletePetTaskOccurrence
+func (c *Client) CompletePetTaskOccurrenceCtx(ctx context.Context, petId string, occurrenceId string) *HttpResponse[PetTaskOccurrenceUpdateResponse] {
+	return c.setTaskOccurrenceStatus(ctx, petId, occurrenceId, TaskOccurrenceComplete)
+}
+
+// SkipPetTaskOccurrence marks an occurrence of a pet task as skipped.
+//
+// Updating occurrences is not part of the documented collection, which only lists them,
+// and is assumed.
+func (c *Client) SkipPetTaskOccurrence(petId string, occurrenceId string) *HttpResponse[PetTaskOccurrenceUpdateResponse] {
+	return c.SkipPetTaskOccurrenceCtx(context.Background(), petId, occurrenceId)
+}
+
+// SkipPetTaskOccurrenceCtx is the context-aware variant of SkipPetTaskOccurrence
+func (c *Client) SkipPetTaskOccurrenceCtx(ctx context.Context, petId string, occurrenceId string) *HttpResponse[PetTaskOccurrenceUpdateResponse] {
+	return c.setTaskOccurrenceStatus(ctx, petId, occurrenceId, TaskOccurrenceSkipped)
+}
+
+// setTaskOccurrenceStatus updates the status of a task occurrence
+func (c *Client) setTaskOccurrenceStatus(ctx context.Context, petId string, occurrenceId string, status string) *HttpResponse[PetTaskOccurrenceUpdateResponse] {
+	return Do[PetTaskOccurrenceUpdateResponse](ctx, c, http.MethodPut, fmt.Sprintf("api/pets/%s/task_occurrences/%s", url.PathEscape(petId), url.PathEscape(occurrenceId)), nil, map[string]any{
+		"task_occurrence": map[string]string{"status": status},
+	})
+}
@@ -0,0 +1,146 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/amattu2/go-whistle-wrapper/whistle"
+	"github.com/go-playground/assert/v2"
+)
+
+// medication is a valid weekly task
+var medication = whistle.PetTaskInput{
+	Title:     "Heartworm pill",
+	TaskType:  whistle.TaskTypeMedication,
+	StartDate: "2026-10-01",
+	DueTime:   "08:30",
+	Recurrence: whistle.PetTaskRecurrence{
+		Frequency:  whistle.TaskFrequencyWeekly,
+		Interval:   1,
+		DaysOfWeek: []string{"monday", "thursday"},
+	},
+}
+
+func TestPetTasks(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusOK, `{"tasks":[{"id":1,"title":"Heartworm pill","recurrence":{"frequency":"weekly"}}]}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.PetTasks("123")
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, "weekly", resp.Response.Tasks[0].Recurrence.Frequency)
+	assert.Equal(t, "/api/pets/123/tasks", last().Path)
+}
+
+func TestCreatePetTask(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusCreated, `{"task":{"id":9,"title":"Heartworm pill"}}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.CreatePetTask("123", medication)
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, 9, resp.Response.Task.ID)
+	assert.Equal(t, http.MethodPost, last().Method)
+	assert.Equal(t, "/api/pets/123/tasks", last().Path)
+	assert.Equal(t, map[string]any{
+		"task": map[string]any{
+			"title":      "Heartworm pill",
+			"task_type":  "medication",
+			"start_date": "2026-10-01",
+			"due_time":   "08:30",
+			"recurrence": map[string]any{
+				"frequency":    "weekly",
+				"interval":     1.0,
+				"days_of_week": []any{"monday", "thursday"},
+			},
+		},
+	}, last().Body)
+}
+
+func TestPetTaskInvalid(t *testing.T) {
+	t.Parallel()
+
+	client := whistle.InitializeBearer("abc123")
+	tests := map[string]func(task *whistle.PetTaskInput){
+		"title":                   func(task *whistle.PetTaskInput) { task.Title = "" },
+		"task_type":               func(task *whistle.PetTaskInput) { task.TaskType = "walk" },
+		"start_date":              func(task *whistle.PetTaskInput) { task.StartDate = "tomorrow" },
+		"due_time":                func(task *whistle.PetTaskInput) { task.DueTime = "8:30pm" },
+		"recurrence.frequency":    func(task *whistle.PetTaskInput) { task.Recurrence.Frequency = "hourly" },
+		"recurrence.interval":     func(task *whistle.PetTaskInput) { task.Recurrence.Interval = -1 },
+		"recurrence.days_of_week": func(task *whistle.PetTaskInput) { task.Recurrence.DaysOfWeek = []string{"funday"} },
+	}
+
+	for field, modify := range tests {
+		var validationErr *whistle.ValidationError
+		task := medication
+		modify(&task)
+
+		assert.Equal(t, true, errors.As(client.UpdatePetTask("123", "9", task).Error, &validationErr))
+		assert.Equal(t, field, validationErr.Field)
+	}
+}
+
+func TestDeletePetTask(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusNoContent, "")
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+
+	assert.Equal(t, true, client.DeletePetTask("123", "9").Response)
+	assert.Equal(t, http.MethodDelete, last().Method)
+	assert.Equal(t, "/api/pets/123/tasks/9", last().Path)
+}
+
+func TestPetTaskOccurrenceStatus(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusOK, `{"task_occurrence":{"id":5,"status":"complete"}}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.CompletePetTaskOccurrence("123", "5")
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, whistle.TaskOccurrenceComplete, resp.Response.TaskOccurrence.Status)
+	assert.Equal(t, http.MethodPut, last().Method)
+	assert.Equal(t, "/api/pets/123/task_occurrences/5", last().Path)
+	assert.Equal(t, map[string]any{"task_occurrence": map[string]any{"status": "complete"}}, last().Body)
+
+	client.SkipPetTaskOccurrence("123", "6")
+	assert.Equal(t, map[string]any{"task_occurrence": map[string]any{"status": "skipped"}}, last().Body)
+}
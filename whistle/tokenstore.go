@@ -0,0 +1,370 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// tokenFileMode is the permission applied to token files
+	tokenFileMode = 0600
+
+	// pbkdf2Iterations is the key derivation work factor of EncryptedFileTokenStore
+	pbkdf2Iterations = 210000
+
+	// saltSize is the random salt length of EncryptedFileTokenStore
+	saltSize = 16
+)
+
+// ErrTokenDecrypt is returned when an encrypted token file cannot be decrypted,
+// usually because the passphrase is wrong
+var ErrTokenDecrypt = errors.New("whistle: unable to decrypt token store")
+
+// TokenKey identifies the stored tokens of an account in an environment,
+// so that production and staging tokens never collide
+type TokenKey struct {
+	Email string `json:"email"`
+	Env   string `json:"env"`
+}
+
+// String returns the normalized key used by the built-in stores
+func (k TokenKey) String() string {
+	return fmt.Sprintf("%s|%s", strings.TrimRight(k.Env, "/"), strings.ToLower(k.Email))
+}
+
+// StoredToken holds the credentials persisted by a TokenStore
+type StoredToken struct {
+	Bearer       string    `json:"bearer"`
+	RefreshToken string    `json:"refresh_token"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TokenStore persists tokens between client instances. The client consults the
+// store before calling api/login and saves the result after a successful login.
+type TokenStore interface {
+	// Load returns the stored token for key, or nil if none is stored
+	Load(key TokenKey) (*StoredToken, error)
+
+	// Save stores the token for key, replacing any existing token
+	Save(key TokenKey, token StoredToken) error
+
+	// Delete removes the stored token for key
+	Delete(key TokenKey) error
+}
+
+// MemoryTokenStore is a TokenStore that keeps tokens in memory for the life of the process
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]StoredToken
+}
+
+// NewMemoryTokenStore creates an empty in-memory token store
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{
+		tokens: map[string]StoredToken{},
+	}
+}
+
+func (s *MemoryTokenStore) Load(key TokenKey) (*StoredToken, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if token, ok := s.tokens[key.String()]; ok {
+		return &token, nil
+	}
+
+	return nil, nil
+}
+
+func (s *MemoryTokenStore) Save(key TokenKey, token StoredToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[key.String()] = token
+
+	return nil
+}
+
+func (s *MemoryTokenStore) Delete(key TokenKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.tokens, key.String())
+
+	return nil
+}
+
+// FileTokenStore is a TokenStore backed by a JSON file readable only by its owner (0600)
+type FileTokenStore struct {
+	file tokenFile
+}
+
+// NewFileTokenStore creates a token store that reads and writes the JSON file at path
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{
+		file: tokenFile{path: path},
+	}
+}
+
+func (s *FileTokenStore) Load(key TokenKey) (*StoredToken, error) {
+	return s.file.load(key)
+}
+
+func (s *FileTokenStore) Save(key TokenKey, token StoredToken) error {
+	return s.file.save(key, &token)
+}
+
+func (s *FileTokenStore) Delete(key TokenKey) error {
+	return s.file.save(key, nil)
+}
+
+// EncryptedFileTokenStore is a TokenStore backed by a file encrypted with AES-256-GCM
+// using a key derived from a passphrase (PBKDF2-HMAC-SHA256)
+type EncryptedFileTokenStore struct {
+	file tokenFile
+}
+
+// NewEncryptedFileTokenStore creates a token store that encrypts the file at path with passphrase
+func NewEncryptedFileTokenStore(path string, passphrase string) *EncryptedFileTokenStore {
+	return &EncryptedFileTokenStore{
+		file: tokenFile{
+			path:    path,
+			encrypt: func(data []byte) ([]byte, error) { return encryptTokens(data, passphrase) },
+			decrypt: func(data []byte) ([]byte, error) { return decryptTokens(data, passphrase) },
+		},
+	}
+}
+
+func (s *EncryptedFileTokenStore) Load(key TokenKey) (*StoredToken, error) {
+	return s.file.load(key)
+}
+
+func (s *EncryptedFileTokenStore) Save(key TokenKey, token StoredToken) error {
+	return s.file.save(key, &token)
+}
+
+func (s *EncryptedFileTokenStore) Delete(key TokenKey) error {
+	return s.file.save(key, nil)
+}
+
+// tokenFile reads and writes a map of tokens to disk, optionally transforming the contents
+type tokenFile struct {
+	mu      sync.Mutex
+	path    string
+	encrypt func([]byte) ([]byte, error)
+	decrypt func([]byte) ([]byte, error)
+}
+
+// load returns the token stored for key, or nil if the file or key does not exist
+func (f *tokenFile) load(key TokenKey) (*StoredToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tokens, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	if token, ok := tokens[key.String()]; ok {
+		return &token, nil
+	}
+
+	return nil, nil
+}
+
+// save stores the token for key, or removes the key if token is nil
+func (f *tokenFile) save(key TokenKey, token *StoredToken) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tokens, err := f.read()
+	if err != nil {
+		return err
+	}
+
+	if token != nil {
+		tokens[key.String()] = *token
+	} else {
+		delete(tokens, key.String())
+	}
+
+	return f.write(tokens)
+}
+
+// read decodes the token file, returning an empty map if it does not exist yet
+func (f *tokenFile) read() (map[string]StoredToken, error) {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]StoredToken{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if f.decrypt != nil {
+		if data, err = f.decrypt(data); err != nil {
+			return nil, err
+		}
+	}
+
+	tokens := map[string]StoredToken{}
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// write atomically replaces the token file with the encoded tokens
+func (f *tokenFile) write(tokens map[string]StoredToken) error {
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if f.encrypt != nil {
+		if data, err = f.encrypt(data); err != nil {
+			return err
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(tokenFileMode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), f.path)
+}
+
+// encryptTokens seals data with a key derived from passphrase. The output is salt | nonce | ciphertext.
+func encryptTokens(data []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	gcm, err := newTokenCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	out := append(salt, nonce...)
+
+	return gcm.Seal(out, nonce, data, nil), nil
+}
+
+// decryptTokens opens data produced by encryptTokens
+func decryptTokens(data []byte, passphrase string) ([]byte, error) {
+	if len(data) < saltSize {
+		return nil, ErrTokenDecrypt
+	}
+
+	gcm, err := newTokenCipher(passphrase, data[:saltSize])
+	if err != nil {
+		return nil, err
+	}
+
+	data = data[saltSize:]
+	if len(data) < gcm.NonceSize() {
+		return nil, ErrTokenDecrypt
+	}
+
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrTokenDecrypt
+	}
+
+	return plain, nil
+}
+
+// newTokenCipher creates an AES-256-GCM cipher keyed by the passphrase and salt
+func newTokenCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(pbkdf2([]byte(passphrase), salt, pbkdf2Iterations, 32))
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// pbkdf2 derives a key of keyLen bytes from password and salt using HMAC-SHA256 (RFC 8018)
+func pbkdf2(password []byte, salt []byte, iterations int, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	blocks := (keyLen + prf.Size() - 1) / prf.Size()
+	key := make([]byte, 0, blocks*prf.Size())
+
+	buf := make([]byte, 4)
+	for block := 1; block <= blocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(buf, uint32(block))
+		prf.Write(buf)
+		u := prf.Sum(nil)
+
+		t := make([]byte, len(u))
+		copy(t, u)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+
+		key = append(key, t...)
+	}
+
+	return key[:keyLen]
+}
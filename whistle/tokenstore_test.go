@@ -0,0 +1,180 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/amattu2/go-whistle-wrapper/whistle"
+	"github.com/go-playground/assert/v2"
+)
+
+var (
+	prodKey    = whistle.TokenKey{Email: "abc@gmail.com", Env: whistle.ProdEnv}
+	stagingKey = whistle.TokenKey{Email: "abc@gmail.com", Env: whistle.StagingEnv}
+)
+
+func TestMemoryTokenStore(t *testing.T) {
+	t.Parallel()
+
+	store := whistle.NewMemoryTokenStore()
+	store.Save(prodKey, whistle.StoredToken{Bearer: "prod"})
+
+	token, err := store.Load(prodKey)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "prod", token.Bearer)
+
+	token, err = store.Load(stagingKey)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, token == nil)
+
+	store.Delete(prodKey)
+	token, _ = store.Load(prodKey)
+	assert.Equal(t, true, token == nil)
+}
+
+func TestFileTokenStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	store := whistle.NewFileTokenStore(path)
+
+	token, err := store.Load(prodKey)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, token == nil)
+
+	assert.Equal(t, nil, store.Save(prodKey, whistle.StoredToken{Bearer: "prod", RefreshToken: "r1"}))
+	assert.Equal(t, nil, store.Save(stagingKey, whistle.StoredToken{Bearer: "staging"}))
+
+	info, err := os.Stat(path)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	token, _ = whistle.NewFileTokenStore(path).Load(prodKey)
+	assert.Equal(t, "prod", token.Bearer)
+	assert.Equal(t, "r1", token.RefreshToken)
+
+	token, _ = whistle.NewFileTokenStore(path).Load(stagingKey)
+	assert.Equal(t, "staging", token.Bearer)
+}
+
+func TestEncryptedFileTokenStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "tokens.enc")
+	store := whistle.NewEncryptedFileTokenStore(path, "correct horse")
+
+	assert.Equal(t, nil, store.Save(prodKey, whistle.StoredToken{Bearer: "secret-bearer"}))
+
+	raw, _ := os.ReadFile(path)
+	assert.Equal(t, false, strings.Contains(string(raw), "secret-bearer"))
+
+	token, err := whistle.NewEncryptedFileTokenStore(path, "correct horse").Load(prodKey)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "secret-bearer", token.Bearer)
+
+	_, err = whistle.NewEncryptedFileTokenStore(path, "wrong").Load(prodKey)
+	assert.Equal(t, true, errors.Is(err, whistle.ErrTokenDecrypt))
+}
+
+func TestClientTokenStore(t *testing.T) {
+	t.Parallel()
+
+	var logins int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/login" {
+			atomic.AddInt32(&logins, 1)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"auth_token":"bearer123","refresh_token":"refresh456"}`))
+			return
+		}
+
+		w.Write([]byte(`{"pets":[]}`))
+	}))
+	defer server.Close()
+
+	store := whistle.NewMemoryTokenStore()
+	for i := 0; i < 3; i++ {
+		client, _ := whistle.NewClient(whistle.Credentials{Email: "abc@gmail.com", Password: "xyz"})
+		client.Env = server.URL
+		client.TokenStore = store
+
+		assert.Equal(t, http.StatusOK, client.Pets().StatusCode)
+		assert.Equal(t, "bearer123", client.Bearer())
+	}
+
+	token, _ := store.Load(whistle.TokenKey{Email: "abc@gmail.com", Env: server.URL})
+	assert.Equal(t, int32(1), atomic.LoadInt32(&logins))
+	assert.Equal(t, "refresh456", token.RefreshToken)
+}
+
+// slowTokenStore is a TokenStore whose Load blocks until release is closed
+type slowTokenStore struct {
+	*whistle.MemoryTokenStore
+	loading chan struct{}
+	release chan struct{}
+}
+
+func (s *slowTokenStore) Load(key whistle.TokenKey) (*whistle.StoredToken, error) {
+	close(s.loading)
+	<-s.release
+
+	return &whistle.StoredToken{Bearer: "stored123"}, nil
+}
+
+func TestClientTokenStoreUnlocked(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"pets":[]}`))
+	}))
+	defer server.Close()
+
+	store := &slowTokenStore{
+		MemoryTokenStore: whistle.NewMemoryTokenStore(),
+		loading:          make(chan struct{}),
+		release:          make(chan struct{}),
+	}
+	client, _ := whistle.NewClient(whistle.Credentials{Email: "abc@gmail.com", Password: "xyz"})
+	client.Env = server.URL
+	client.TokenStore = store
+
+	done := make(chan int)
+	go func() {
+		done <- client.Pets().StatusCode
+	}()
+
+	// Readers are not blocked while the store is loading
+	<-store.loading
+	assert.Equal(t, "", client.Bearer())
+
+	close(store.release)
+	assert.Equal(t, http.StatusOK, <-done)
+	assert.Equal(t, "stored123", client.Bearer())
+}
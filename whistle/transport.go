@@ -0,0 +1,121 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle
+
+import (
+	"net/http"
+	"time"
+)
+
+// defaultTransport is the pooled transport shared by clients without their own
+var defaultTransport http.RoundTripper = newDefaultTransport()
+
+// Middleware wraps a RoundTripper to add cross-cutting behavior such as
+// header injection, logging or tracing
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function into a http.RoundTripper
+type RoundTripperFunc func(request *http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// newDefaultTransport creates the pooled transport shared by clients
+func newDefaultTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = 20
+	transport.IdleConnTimeout = 90 * time.Second
+
+	return transport
+}
+
+// httpClient returns the HTTP client used to send requests.
+//
+// HTTPClient takes precedence over Timeout, and the Middleware chain wraps the
+// transport of the request.
+func (c *Client) httpClient() *http.Client {
+	client := c.plainHTTPClient()
+	client.Transport = c.middlewareChain()
+
+	return client
+}
+
+// plainHTTPClient returns the HTTP client used to send requests without the Middleware chain.
+//
+// HTTPClient takes precedence over Timeout, and the request is sent through the base transport.
+func (c *Client) plainHTTPClient() *http.Client {
+	client := &http.Client{Timeout: c.Timeout}
+	if c.HTTPClient != nil {
+		copied := *c.HTTPClient
+		client = &copied
+	}
+	client.Transport = c.baseTransport()
+
+	return client
+}
+
+// baseTransport returns the transport wrapped by the Middleware chain.
+//
+// The transport of HTTPClient takes precedence over Transport.
+func (c *Client) baseTransport() http.RoundTripper {
+	if c.HTTPClient != nil && c.HTTPClient.Transport != nil {
+		return c.HTTPClient.Transport
+	}
+	if c.Transport != nil {
+		return c.Transport
+	}
+
+	return defaultTransport
+}
+
+// middlewareChain returns the Middleware chain, with the first middleware being the outermost.
+//
+// The chain is built once so that stateful middleware persists across requests, and
+// is rebuilt when Middleware is replaced. It resolves the base transport on each request.
+func (c *Client) middlewareChain() http.RoundTripper {
+	if len(c.Middleware) == 0 {
+		return c.baseTransport()
+	}
+
+	c.chainMu.Lock()
+	defer c.chainMu.Unlock()
+
+	if c.chain == nil || !sameMiddleware(c.chainMiddleware, c.Middleware) {
+		var transport http.RoundTripper = RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			return c.baseTransport().RoundTrip(request)
+		})
+		for i := len(c.Middleware) - 1; i >= 0; i-- {
+			transport = c.Middleware[i](transport)
+		}
+
+		c.chain, c.chainMiddleware = transport, c.Middleware
+	}
+
+	return c.chain
+}
+
+// sameMiddleware checks whether a and b are the same Middleware slice
+func sameMiddleware(a []Middleware, b []Middleware) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
@@ -0,0 +1,135 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/amattu2/go-whistle-wrapper/whistle"
+	"github.com/go-playground/assert/v2"
+)
+
+// stubTransport answers every request with the provided JSON body
+func stubTransport(body string) whistle.RoundTripperFunc {
+	return func(request *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    request,
+		}, nil
+	}
+}
+
+func TestCustomTransport(t *testing.T) {
+	t.Parallel()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Transport = stubTransport(`{"breeds":[{"id":1,"name":"Beagle"}]}`)
+
+	resp := client.Breeds("dogs")
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Beagle", resp.Response.Breeds[0].Name)
+}
+
+func TestCustomHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Transport = stubTransport(`{"breeds":[]}`)
+	client.HTTPClient = &http.Client{Transport: stubTransport(`{"breeds":[{"id":2,"name":"Poodle"}]}`)}
+
+	resp := client.Breeds("dogs")
+
+	assert.Equal(t, "Poodle", resp.Response.Breeds[0].Name)
+}
+
+func TestMiddlewareChain(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "outer,inner", r.Header.Get("X-Trace"))
+		w.Write([]byte(`{"breeds":[]}`))
+	}))
+	defer server.Close()
+
+	var order []string
+	trace := func(name string) whistle.Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return whistle.RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				request = request.Clone(request.Context())
+				if existing := request.Header.Get("X-Trace"); existing != "" {
+					name = existing + "," + name
+				}
+				request.Header.Set("X-Trace", name)
+
+				return next.RoundTrip(request)
+			})
+		}
+	}
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	client.Middleware = []whistle.Middleware{trace("outer"), trace("inner")}
+
+	resp := client.Breeds("dogs")
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"outer", "inner"}, order)
+}
+
+func TestMiddlewareBuiltOnce(t *testing.T) {
+	t.Parallel()
+
+	var built, requests int32
+	counter := func(next http.RoundTripper) http.RoundTripper {
+		atomic.AddInt32(&built, 1)
+		return whistle.RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&requests, 1)
+			return next.RoundTrip(request)
+		})
+	}
+
+	client := whistle.InitializeBearer("abc123")
+	client.Transport = stubTransport(`{"breeds":[]}`)
+	client.Middleware = []whistle.Middleware{counter}
+
+	for i := 0; i < 3; i++ {
+		client.Breeds("dogs")
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&built))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	// Replacing the chain builds it again
+	client.Middleware = []whistle.Middleware{counter}
+	client.Breeds("dogs")
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&built))
+}
@@ -22,10 +22,12 @@
 package whistle
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
 	"strings"
 )
 
@@ -55,7 +57,17 @@ type CancellationReason struct {
 }
 
 type CancellationPreviewResponse struct {
-	Errors []Error `json:"errors"`
+	Errors  []Error             `json:"errors"`
+	Preview CancellationPreview `json:"preview"`
+}
+
+type CancellationPreview struct {
+	EffectiveOn    string  `json:"effective_on"`
+	PaidThrough    string  `json:"paid_through"`
+	ProratedAmount float64 `json:"prorated_amount"`
+	RefundAmount   float64 `json:"refund_amount"`
+	AmountDue      float64 `json:"amount_due"`
+	Currency       string  `json:"currency"`
 }
 
 type UsersResponse struct {
@@ -108,6 +120,24 @@ type PhoneNumber struct {
 	Verified bool   `json:"verified"`
 }
 
+type PhoneNumberResponse struct {
+	PhoneNumber PhoneNumber `json:"phone_number"`
+}
+
+// NotificationSettingsUpdate is a partial update of the notification settings of the user.
+//
+// Only the categories present in the maps and the fields that are set are changed.
+// The secondary lists replace the current ones, set them to Ptr([]string{}) to clear them.
+type NotificationSettingsUpdate struct {
+	EmailCategories       map[string]bool `json:"email_categories,omitempty"`
+	PushCategories        map[string]bool `json:"push_categories,omitempty"`
+	SMSCategories         map[string]bool `json:"sms_categories,omitempty"`
+	SendEmail             *bool           `json:"send_email,omitempty"`
+	SendSMS               *bool           `json:"send_sms,omitempty"`
+	SecondaryEmails       *[]string       `json:"secondary_emails,omitempty"`
+	SecondaryPhoneNumbers *[]string       `json:"secondary_phone_numbers,omitempty"`
+}
+
 type CreditCard struct {
 	CardType        string `json:"card_type"`
 	ExpirationMonth int    `json:"expiration_month"`
@@ -142,234 +172,174 @@ type Friends struct {
 // Users returns information about the current user
 //
 // Deprecated: Use Me() instead
-func (c Client) Users() *HttpResponse[UsersResponse] {
-	resp, err := c.get("api/users", nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[UsersResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := UsersResponse{}
-	json.Unmarshal(body, &result)
+func (c *Client) Users() *HttpResponse[UsersResponse] {
+	return c.UsersCtx(context.Background())
+}
 
-	return &HttpResponse[UsersResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// UsersCtx is the context-aware variant of Users
+func (c *Client) UsersCtx(ctx context.Context) *HttpResponse[UsersResponse] {
+	return Do[UsersResponse](ctx, c, http.MethodGet, "api/users", nil, nil)
 }
 
 // Me returns information about the current user
-func (c Client) Me() *HttpResponse[MeResponse] {
-	resp, err := c.get("api/users/me", nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[MeResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := MeResponse{}
-	json.Unmarshal(body, &result)
+func (c *Client) Me() *HttpResponse[MeResponse] {
+	return c.MeCtx(context.Background())
+}
 
-	return &HttpResponse[MeResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// MeCtx is the context-aware variant of Me
+func (c *Client) MeCtx(ctx context.Context) *HttpResponse[MeResponse] {
+	return Do[MeResponse](ctx, c, http.MethodGet, "api/users/me", nil, nil)
 }
 
 // CheckEmail checks the provided email address to see if it is already in use
-func (c Client) CheckEmail(email string) *HttpResponse[bool] {
-	email = strings.ReplaceAll(email, "@", "%40")
-	email = strings.ReplaceAll(email, ".", "%2E")
+func (c *Client) CheckEmail(email string) *HttpResponse[bool] {
+	return c.CheckEmailCtx(context.Background(), email)
+}
 
-	resp, err := c.get(fmt.Sprintf("api/users/emails/%s", email), nil, true)
+// emailEscaping escapes the characters url.PathEscape keeps in an email, which the API
+// would otherwise read as a format suffix
+var emailEscaping = strings.NewReplacer("@", "%40", ".", "%2E")
 
-	if err != nil {
-		return &HttpResponse[bool]{
-			Error: err,
-			Raw:   resp,
-		}
-	}
+// CheckEmailCtx is the context-aware variant of CheckEmail
+func (c *Client) CheckEmailCtx(ctx context.Context, email string) *HttpResponse[bool] {
+	resp := request[json.RawMessage](ctx, c, http.MethodGet, fmt.Sprintf("api/users/emails/%s", emailEscaping.Replace(url.PathEscape(email))), nil, nil, nil, http.StatusNotFound)
 
-	defer resp.Body.Close()
-
-	if http.StatusNoContent == resp.StatusCode {
-		return &HttpResponse[bool]{
-			StatusCode: resp.StatusCode,
-			Response:   true,
-			Raw:        resp,
-		}
-	} else {
-		return &HttpResponse[bool]{
-			StatusCode: resp.StatusCode,
-			Response:   false,
-			Raw:        resp,
-		}
+	// The body is not used, only the status tells whether the email is in use
+	result := relay[bool](resp)
+	var decodeErr *DecodeError
+	if errors.As(result.Error, &decodeErr) {
+		result.Error = nil
 	}
+	result.Response = result.Error == nil && result.StatusCode == http.StatusNoContent
+
+	return result
 }
 
 // InvitationCodes returns the pet information for the provided invitation code
-func (c Client) InvitationCodes(code string) *HttpResponse[InvitationCodeResponse] {
-	resp, err := c.get(fmt.Sprintf("api/users/invitation_codes/%s", code), nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[InvitationCodeResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := InvitationCodeResponse{}
-	json.Unmarshal(body, &result)
+func (c *Client) InvitationCodes(code string) *HttpResponse[InvitationCodeResponse] {
+	return c.InvitationCodesCtx(context.Background(), code)
+}
 
-	return &HttpResponse[InvitationCodeResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// InvitationCodesCtx is the context-aware variant of InvitationCodes
+func (c *Client) InvitationCodesCtx(ctx context.Context, code string) *HttpResponse[InvitationCodeResponse] {
+	return Do[InvitationCodeResponse](ctx, c, http.MethodGet, fmt.Sprintf("api/users/invitation_codes/%s", url.PathEscape(code)), nil, nil)
 }
 
 // ApplicationState provides information about the current application state
-func (c Client) ApplicationState() *HttpResponse[ApplicationStateResponse] {
-	resp, err := c.get("api/users/application_state", nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[ApplicationStateResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
-
-	defer resp.Body.Close()
-
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := ApplicationStateResponse{}
-	json.Unmarshal(body, &result)
+func (c *Client) ApplicationState() *HttpResponse[ApplicationStateResponse] {
+	return c.ApplicationStateCtx(context.Background())
+}
 
-	return &HttpResponse[ApplicationStateResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// ApplicationStateCtx is the context-aware variant of ApplicationState
+func (c *Client) ApplicationStateCtx(ctx context.Context) *HttpResponse[ApplicationStateResponse] {
+	return Do[ApplicationStateResponse](ctx, c, http.MethodGet, "api/users/application_state", nil, nil)
 }
 
 // CreditCard provides information about the current user's credit card on file
 //
 // Deprecated: Unknown replacement.
-func (c Client) CreditCard() *HttpResponse[CreditCard] {
-	resp, err := c.get("api/users/credit_card", map[string]string{"Accept": "application/json"}, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[CreditCard]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
+func (c *Client) CreditCard() *HttpResponse[CreditCard] {
+	return c.CreditCardCtx(context.Background())
+}
 
-	defer resp.Body.Close()
+// CreditCardCtx is the context-aware variant of CreditCard
+func (c *Client) CreditCardCtx(ctx context.Context) *HttpResponse[CreditCard] {
+	return request[CreditCard](ctx, c, http.MethodGet, "api/users/credit_card", nil, map[string]string{"Accept": "application/json"}, nil)
+}
 
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := CreditCard{}
-	json.Unmarshal(body, &result)
+// Subscriptions provides a listing of the current user's subscriptions
+func (c *Client) Subscriptions() *HttpResponse[SubscriptionsResponse] {
+	return c.SubscriptionsCtx(context.Background())
+}
 
-	return &HttpResponse[CreditCard]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// SubscriptionsCtx is the context-aware variant of Subscriptions
+func (c *Client) SubscriptionsCtx(ctx context.Context) *HttpResponse[SubscriptionsResponse] {
+	return Do[SubscriptionsResponse](ctx, c, http.MethodGet, "api/users/subscriptions", nil, nil)
 }
 
-// Subscriptions provides a listing of the current user's subscriptions
-func (c Client) Subscriptions() *HttpResponse[SubscriptionsResponse] {
-	resp, err := c.get("api/users/subscriptions", nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[SubscriptionsResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
+// CancellationPreview returns the amounts refunded or due when cancelling a subscription
+func (c *Client) CancellationPreview(subId string) *HttpResponse[CancellationPreviewResponse] {
+	return c.CancellationPreviewCtx(context.Background(), subId)
+}
 
-	defer resp.Body.Close()
+// CancellationPreviewCtx is the context-aware variant of CancellationPreview
+func (c *Client) CancellationPreviewCtx(ctx context.Context, subId string) *HttpResponse[CancellationPreviewResponse] {
+	return Do[CancellationPreviewResponse](ctx, c, http.MethodGet, fmt.Sprintf("api/subscriptions/%s/cancellation/preview", url.PathEscape(subId)), nil, nil)
+}
 
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := SubscriptionsResponse{}
-	json.Unmarshal(body, &result)
+// CancellationReasons returns a list of reasons why a user may be cancelling their subscription
+func (c *Client) CancellationReasons(subId string) *HttpResponse[CancellationReasonsResponse] {
+	return c.CancellationReasonsCtx(context.Background(), subId)
+}
 
-	return &HttpResponse[SubscriptionsResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
-	}
+// CancellationReasonsCtx is the context-aware variant of CancellationReasons
+func (c *Client) CancellationReasonsCtx(ctx context.Context, subId string) *HttpResponse[CancellationReasonsResponse] {
+	return Do[CancellationReasonsResponse](ctx, c, http.MethodGet, fmt.Sprintf("api/subscriptions/%s/cancellation/reasons", url.PathEscape(subId)), nil, nil)
 }
 
-// Todo: Figure out what this does
-func (c Client) CancellationPreview(subId string) *HttpResponse[CancellationPreviewResponse] {
-	resp, err := c.get(fmt.Sprintf("api/users/subscriptions/%s/cancellation/preview", subId), nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[CancellationPreviewResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
+// phoneFormatting removes the formatting characters allowed in phone numbers
+var phoneFormatting = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+// AddPhoneNumber adds a phone number to the current user. A verification code is sent to it by SMS.
+func (c *Client) AddPhoneNumber(number string) *HttpResponse[PhoneNumberResponse] {
+	return c.AddPhoneNumberCtx(context.Background(), number)
+}
+
+// AddPhoneNumberCtx is the context-aware variant of AddPhoneNumber
+func (c *Client) AddPhoneNumberCtx(ctx context.Context, number string) *HttpResponse[PhoneNumberResponse] {
+	digits := strings.TrimPrefix(phoneFormatting.Replace(number), "+")
+	if len(digits) < 10 || len(digits) > 15 || strings.Trim(digits, "0123456789") != "" {
+		return invalid[PhoneNumberResponse](&ValidationError{Field: "number", Reason: "must be a phone number of 10 to 15 digits"})
 	}
 
-	defer resp.Body.Close()
+	return Do[PhoneNumberResponse](ctx, c, http.MethodPost, "api/phone_numbers", nil, map[string]string{"number": number})
+}
 
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := CancellationPreviewResponse{}
-	json.Unmarshal(body, &result)
+// VerifyPhoneNumber confirms a phone number with the code it received by SMS.
+//
+// The endpoint is not part of the documented collection, which only covers adding and
+// deleting phone numbers, and is assumed.
+func (c *Client) VerifyPhoneNumber(phoneId string, code string) *HttpResponse[PhoneNumberResponse] {
+	return c.VerifyPhoneNumberCtx(context.Background(), phoneId, code)
+}
 
-	return &HttpResponse[CancellationPreviewResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
+// VerifyPhoneNumberCtx is the context-aware variant of VerifyPhoneNumber
+func (c *Client) VerifyPhoneNumberCtx(ctx context.Context, phoneId string, code string) *HttpResponse[PhoneNumberResponse] {
+	if strings.TrimSpace(code) == "" {
+		return invalid[PhoneNumberResponse](&ValidationError{Field: "verification_code", Reason: "must not be empty"})
 	}
+
+	return Do[PhoneNumberResponse](ctx, c, http.MethodPut, fmt.Sprintf("api/phone_numbers/%s/verify", url.PathEscape(phoneId)), nil, map[string]string{
+		"verification_code": code,
+	})
 }
 
-// CancellationReasons returns a list of reasons why a user may be cancelling their subscription
-func (c Client) CancellationReasons(subId string) *HttpResponse[CancellationReasonsResponse] {
-	resp, err := c.get(fmt.Sprintf("api/users/subscriptions/%s/cancellation/preview", subId), nil, true)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return &HttpResponse[CancellationReasonsResponse]{
-			StatusCode: resp.StatusCode,
-			Error:      err,
-			Raw:        resp,
-		}
-	}
+// DeletePhoneNumber removes a phone number from the current user
+func (c *Client) DeletePhoneNumber(phoneId string) *HttpResponse[bool] {
+	return c.DeletePhoneNumberCtx(context.Background(), phoneId)
+}
 
-	defer resp.Body.Close()
+// DeletePhoneNumberCtx is the context-aware variant of DeletePhoneNumber
+func (c *Client) DeletePhoneNumberCtx(ctx context.Context, phoneId string) *HttpResponse[bool] {
+	return confirm(ctx, c, http.MethodDelete, "api/phone_numbers/"+url.PathEscape(phoneId), nil)
+}
 
-	// Parse json response
-	body, _ := io.ReadAll(resp.Body)
-	result := CancellationReasonsResponse{}
-	json.Unmarshal(body, &result)
+// UpdateNotificationSettings updates the email, push and SMS notification settings of the current user.
+//
+// Updating api/users/me is not part of the documented collection and is assumed from
+// the notification settings it returns.
+func (c *Client) UpdateNotificationSettings(update NotificationSettingsUpdate) *HttpResponse[MeResponse] {
+	return c.UpdateNotificationSettingsCtx(context.Background(), update)
+}
 
-	return &HttpResponse[CancellationReasonsResponse]{
-		StatusCode: resp.StatusCode,
-		Response:   result,
-		Raw:        resp,
+// UpdateNotificationSettingsCtx is the context-aware variant of UpdateNotificationSettings
+func (c *Client) UpdateNotificationSettingsCtx(ctx context.Context, update NotificationSettingsUpdate) *HttpResponse[MeResponse] {
+	if len(update.EmailCategories) == 0 && len(update.PushCategories) == 0 && len(update.SMSCategories) == 0 &&
+		update.SendEmail == nil && update.SendSMS == nil && update.SecondaryEmails == nil && update.SecondaryPhoneNumbers == nil {
+		return invalid[MeResponse](&ValidationError{Field: "notification_settings", Reason: "no fields are set"})
 	}
+
+	return Do[MeResponse](ctx, c, http.MethodPut, "api/users/me", nil, map[string]any{
+		"user": map[string]any{"notification_settings": update},
+	})
 }
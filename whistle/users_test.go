@@ -22,9 +22,11 @@
 package whistle_test
 
 import (
+	"errors"
 	"net/http"
 	"testing"
 
+	"github.com/amattu2/go-whistle-wrapper/whistle"
 	"github.com/go-playground/assert/v2"
 )
 
@@ -71,6 +73,52 @@ func TestCheckEmailNonExisting(t *testing.T) {
 	assert.Equal(t, resp.Response, false)
 }
 
+func TestCheckEmailEscaping(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusNoContent, "")
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.CheckEmail("a?b@x.com")
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, true, resp.Response)
+	assert.Equal(t, "/api/users/emails/a%3Fb%40x%2Ecom", last().RawPath)
+	assert.Equal(t, 0, len(last().Query))
+}
+
+func TestCheckEmailNotFound(t *testing.T) {
+	t.Parallel()
+
+	server, _ := captureServer(http.StatusNotFound, "<html>Not Found</html>")
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.CheckEmail("nobody@x.com")
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, false, resp.Response)
+}
+
+func TestCheckEmailError(t *testing.T) {
+	t.Parallel()
+
+	server, _ := captureServer(http.StatusUnauthorized, `{"errors":[{"message":"Unauthorized"}]}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.CheckEmail("nobody@x.com")
+
+	var apiErr *whistle.APIError
+	assert.Equal(t, true, errors.As(resp.Error, &apiErr))
+	assert.Equal(t, false, resp.Response)
+}
+
 func TestInvitationCodes(t *testing.T) {
 	t.Skip("Cannot test due to dependence on changing states")
 }
@@ -113,3 +161,93 @@ func TestSubscriptions(t *testing.T) {
 
 	t.Skip("Cannot test due to dependence on changing states")
 }
+
+func TestAddPhoneNumber(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusCreated, `{"phone_number":{"id":7,"number":"+15555550100","verified":false}}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.AddPhoneNumber("+1 (555) 555-0100")
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, 7, resp.Response.PhoneNumber.ID)
+	assert.Equal(t, http.MethodPost, last().Method)
+	assert.Equal(t, "/api/phone_numbers", last().Path)
+	assert.Equal(t, map[string]any{"number": "+1 (555) 555-0100"}, last().Body)
+}
+
+func TestAddPhoneNumberInvalid(t *testing.T) {
+	t.Parallel()
+
+	client := whistle.InitializeBearer("abc123")
+
+	for _, number := range []string{"", "555-0100", "+1 555 CALL NOW", "1234567890123456"} {
+		var validationErr *whistle.ValidationError
+		assert.Equal(t, true, errors.As(client.AddPhoneNumber(number).Error, &validationErr))
+	}
+}
+
+func TestVerifyPhoneNumber(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusOK, `{"phone_number":{"id":7,"verified":true}}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.VerifyPhoneNumber("7", "123456")
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, true, resp.Response.PhoneNumber.Verified)
+	assert.Equal(t, "/api/phone_numbers/7/verify", last().Path)
+	assert.Equal(t, map[string]any{"verification_code": "123456"}, last().Body)
+}
+
+func TestDeletePhoneNumber(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusNoContent, "")
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.DeletePhoneNumber("7")
+
+	assert.Equal(t, true, resp.Response)
+	assert.Equal(t, http.MethodDelete, last().Method)
+	assert.Equal(t, "/api/phone_numbers/7", last().Path)
+}
+
+func TestUpdateNotificationSettings(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusOK, `{"user":{"notification_settings":{"send_sms":true,"sms_categories":{"battery":true}}}}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.UpdateNotificationSettings(whistle.NotificationSettingsUpdate{
+		SMSCategories:   map[string]bool{"battery": true},
+		SendSMS:         whistle.Ptr(true),
+		SecondaryEmails: whistle.Ptr([]string{}),
+	})
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, true, resp.Response.User.NotificationSettings.SendSMS)
+	assert.Equal(t, http.MethodPut, last().Method)
+	assert.Equal(t, map[string]any{
+		"user": map[string]any{
+			"notification_settings": map[string]any{
+				"sms_categories":   map[string]any{"battery": true},
+				"send_sms":         true,
+				"secondary_emails": []any{},
+			},
+		},
+	}, last().Body)
+
+	var validationErr *whistle.ValidationError
+	assert.Equal(t, true, errors.As(client.UpdateNotificationSettings(whistle.NotificationSettingsUpdate{}).Error, &validationErr))
+}
@@ -0,0 +1,204 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+type WifiNetworkResponse struct {
+	Errors      []Error     `json:"errors"`
+	WifiNetwork WifiNetwork `json:"wifi_network"`
+}
+
+// WifiNetworkInput describes a Wifi network known to a device.
+//
+// Name and PlaceId are left unchanged when empty, PetIds is always replaced.
+type WifiNetworkInput struct {
+	SSID    string `json:"ssid"`
+	Name    string `json:"name,omitempty"`
+	PlaceId int    `json:"place_id,omitempty"` // See Places()
+	PetIds  []int  `json:"pet_ids"`
+}
+
+// WifiReconcileResult lists the changes applied by ReconcileWifiNetworks
+type WifiReconcileResult struct {
+	Added   []WifiNetwork
+	Updated []WifiNetwork
+	Removed []WifiNetwork
+}
+
+// validate checks the SSID of the network
+func (w WifiNetworkInput) validate() error {
+	if len(w.SSID) == 0 || len(w.SSID) > 32 {
+		return &ValidationError{Field: "ssid", Reason: "must be between 1 and 32 bytes"}
+	}
+
+	return nil
+}
+
+// request returns the body sent to the API, with PetIds never null
+func (w WifiNetworkInput) request() map[string]any {
+	if w.PetIds == nil {
+		w.PetIds = []int{}
+	}
+
+	return map[string]any{"wifi_network": w}
+}
+
+// differs checks whether the network must be updated to match the input
+func (w WifiNetworkInput) differs(network WifiNetwork) bool {
+	if (w.Name != "" && w.Name != network.Name) || (w.PlaceId != 0 && w.PlaceId != network.PlaceId) {
+		return true
+	}
+	if len(w.PetIds) != len(network.PetIds) {
+		return true
+	}
+
+	want, have := append([]int{}, w.PetIds...), append([]int{}, network.PetIds...)
+	sort.Ints(want)
+	sort.Ints(have)
+	for i := range want {
+		if want[i] != have[i] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddWifiNetwork adds a Wifi network to a device.
+//
+// Writing Wifi networks is not part of the documented collection, which only lists them,
+// and is assumed.
+func (c *Client) AddWifiNetwork(deviceId string, network WifiNetworkInput) *HttpResponse[WifiNetworkResponse] {
+	return c.AddWifiNetworkCtx(context.Background(), deviceId, network)
+}
+
+// AddWifiNetworkCtx is the context-aware variant of AddWifiNetwork
+func (c *Client) AddWifiNetworkCtx(ctx context.Context, deviceId string, network WifiNetworkInput) *HttpResponse[WifiNetworkResponse] {
+	if err := network.validate(); err != nil {
+		return invalid[WifiNetworkResponse](err)
+	}
+
+	return Do[WifiNetworkResponse](ctx, c, http.MethodPost, fmt.Sprintf("api/devices/%s/wifi_networks", url.PathEscape(deviceId)), nil, network.request())
+}
+
+// UpdateWifiNetwork renames a Wifi network of a device or reassigns it to a place and pets.
+//
+// The endpoint is not part of the documented collection and is assumed.
+func (c *Client) UpdateWifiNetwork(deviceId string, networkId int, network WifiNetworkInput) *HttpResponse[WifiNetworkResponse] {
+	return c.UpdateWifiNetworkCtx(context.Background(), deviceId, networkId, network)
+}
+
+// UpdateWifiNetworkCtx is the context-aware variant of UpdateWifiNetwork
+func (c *Client) UpdateWifiNetworkCtx(ctx context.Context, deviceId string, networkId int, network WifiNetworkInput) *HttpResponse[WifiNetworkResponse] {
+	if err := network.validate(); err != nil {
+		return invalid[WifiNetworkResponse](err)
+	}
+
+	return Do[WifiNetworkResponse](ctx, c, http.MethodPut, fmt.Sprintf("api/devices/%s/wifi_networks/%d", url.PathEscape(deviceId), networkId), nil, network.request())
+}
+
+// DeleteWifiNetwork removes a Wifi network from a device.
+//
+// The endpoint is not part of the documented collection and is assumed.
+func (c *Client) DeleteWifiNetwork(deviceId string, networkId int) *HttpResponse[bool] {
+	return c.DeleteWifiNetworkCtx(context.Background(), deviceId, networkId)
+}
+
+// DeleteWifiNetworkCtx is the context-aware variant of DeleteWifiNetwork
+func (c *Client) DeleteWifiNetworkCtx(ctx context.Context, deviceId string, networkId int) *HttpResponse[bool] {
+	return confirm(ctx, c, http.MethodDelete, fmt.Sprintf("api/devices/%s/wifi_networks/%d", url.PathEscape(deviceId), networkId), nil)
+}
+
+// ReconcileWifiNetworks makes the Wifi networks of a device match the desired networks, by SSID.
+// Missing networks are added, differing ones updated and the others removed, including
+// duplicates of a desired SSID.
+//
+// Changes are applied in that order and stop at the first error, returning the changes applied so far.
+// They rely on the assumed endpoints of AddWifiNetwork, UpdateWifiNetwork and DeleteWifiNetwork.
+func (c *Client) ReconcileWifiNetworks(ctx context.Context, deviceId string, desired []WifiNetworkInput) (*WifiReconcileResult, error) {
+	wanted := map[string]WifiNetworkInput{}
+	for _, network := range desired {
+		if err := network.validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := wanted[network.SSID]; ok {
+			return nil, &ValidationError{Field: "ssid", Reason: fmt.Sprintf("%q is listed more than once", network.SSID)}
+		}
+		wanted[network.SSID] = network
+	}
+
+	current := c.DeviceWifiNetworksCtx(ctx, deviceId)
+	if current.Error != nil {
+		return nil, current.Error
+	}
+
+	// Keep a single network per desired SSID, preferring one that already matches
+	existing := map[string]WifiNetwork{}
+	for _, network := range current.Response.WifiNetworks {
+		input, ok := wanted[network.SSID]
+		if !ok {
+			continue
+		}
+		if found, ok := existing[network.SSID]; !ok || (input.differs(found) && !input.differs(network)) {
+			existing[network.SSID] = network
+		}
+	}
+
+	result := &WifiReconcileResult{}
+	for _, network := range desired {
+		found, ok := existing[network.SSID]
+		switch {
+		case !ok:
+			resp := c.AddWifiNetworkCtx(ctx, deviceId, network)
+			if resp.Error != nil {
+				return result, resp.Error
+			}
+			result.Added = append(result.Added, resp.Response.WifiNetwork)
+		case network.differs(found):
+			resp := c.UpdateWifiNetworkCtx(ctx, deviceId, found.ID, network)
+			if resp.Error != nil {
+				return result, resp.Error
+			}
+			result.Updated = append(result.Updated, resp.Response.WifiNetwork)
+		}
+	}
+
+	for _, network := range current.Response.WifiNetworks {
+		if kept, ok := existing[network.SSID]; ok && kept.ID == network.ID {
+			continue
+		}
+
+		if resp := c.DeleteWifiNetworkCtx(ctx, deviceId, network.ID); resp.Error != nil {
+			return result, resp.Error
+		}
+		result.Removed = append(result.Removed, network)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,145 @@
+/*
+ * Produced: Sun Oct 18 2026
+ * Author: Alec M.
+ * GitHub: https://amattu.com/links/github
+ * Copyright: (C) 2023 Alec M.
+ * License: License GNU Affero General Public License v3.0
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package whistle_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/amattu2/go-whistle-wrapper/whistle"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestAddWifiNetwork(t *testing.T) {
+	t.Parallel()
+
+	server, last := captureServer(http.StatusCreated, `{"wifi_network":{"id":3,"ssid":"home"}}`)
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+	resp := client.AddWifiNetwork("ABC123", whistle.WifiNetworkInput{SSID: "home", PlaceId: 10})
+
+	assert.Equal(t, nil, resp.Error)
+	assert.Equal(t, 3, resp.Response.WifiNetwork.ID)
+	assert.Equal(t, http.MethodPost, last().Method)
+	assert.Equal(t, "/api/devices/ABC123/wifi_networks", last().Path)
+	assert.Equal(t, map[string]any{"wifi_network": map[string]any{"ssid": "home", "place_id": 10.0, "pet_ids": []any{}}}, last().Body)
+
+	var validationErr *whistle.ValidationError
+	assert.Equal(t, true, errors.As(client.AddWifiNetwork("ABC123", whistle.WifiNetworkInput{}).Error, &validationErr))
+}
+
+func TestReconcileWifiNetworks(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"wifi_networks":[{"id":1,"ssid":"old-router"},{"id":2,"ssid":"home","pet_ids":[1]},{"id":4,"ssid":"office","pet_ids":[2,1]}]}`))
+		case http.MethodPost:
+			w.Write([]byte(`{"wifi_network":{"id":3,"ssid":"kennel"}}`))
+		case http.MethodPut:
+			w.Write([]byte(`{"wifi_network":{"id":2,"ssid":"home","pet_ids":[1,2]}}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+
+	result, err := client.ReconcileWifiNetworks(context.Background(), "ABC123", []whistle.WifiNetworkInput{
+		{SSID: "home", PetIds: []int{1, 2}},
+		{SSID: "office", PetIds: []int{1, 2}},
+		{SSID: "kennel"},
+	})
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "kennel", result.Added[0].SSID)
+	assert.Equal(t, 2, result.Updated[0].ID)
+	assert.Equal(t, "old-router", result.Removed[0].SSID)
+	assert.Equal(t, []string{
+		"GET /api/devices/ABC123/wifi_networks",
+		"PUT /api/devices/ABC123/wifi_networks/2",
+		"POST /api/devices/ABC123/wifi_networks",
+		"DELETE /api/devices/ABC123/wifi_networks/1",
+	}, calls)
+}
+
+func TestReconcileWifiNetworksDeviceDuplicates(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"wifi_networks":[{"id":1,"ssid":"home"},{"id":2,"ssid":"home","pet_ids":[1]},{"id":3,"ssid":"home"}]}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := whistle.InitializeBearer("abc123")
+	client.Env = server.URL
+
+	result, err := client.ReconcileWifiNetworks(context.Background(), "ABC123", []whistle.WifiNetworkInput{
+		{SSID: "home", PetIds: []int{1}},
+	})
+
+	// The matching network is kept and the other duplicates removed
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(result.Updated))
+	assert.Equal(t, 2, len(result.Removed))
+	assert.Equal(t, []string{
+		"GET /api/devices/ABC123/wifi_networks",
+		"DELETE /api/devices/ABC123/wifi_networks/1",
+		"DELETE /api/devices/ABC123/wifi_networks/3",
+	}, calls)
+}
+
+func TestReconcileWifiNetworksDuplicate(t *testing.T) {
+	t.Parallel()
+
+	client := whistle.InitializeBearer("abc123")
+	_, err := client.ReconcileWifiNetworks(context.Background(), "ABC123", []whistle.WifiNetworkInput{{SSID: "home"}, {SSID: "home"}})
+
+	var validationErr *whistle.ValidationError
+	assert.Equal(t, true, errors.As(err, &validationErr))
+}